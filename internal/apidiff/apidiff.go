@@ -0,0 +1,360 @@
+// Package apidiff compares the exported API of the packages of a Go module at
+// two commits and classifies the changes as compatible or incompatible.
+package apidiff
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type Change struct {
+	// Package is the module relative directory of the package, "." for the
+	// module root.
+	Package      string
+	Name         string
+	Message      string
+	Incompatible bool
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Name == "":
+		return c.Package + ": " + c.Message
+	case c.Package == ".":
+		return c.Name + ": " + c.Message
+	}
+	return c.Package + "." + c.Name + ": " + c.Message
+}
+
+type Report struct {
+	Changes []Change
+}
+
+// Bump returns the bump required by the changes: major for incompatible
+// changes and minor for additions.
+func (r *Report) Bump() model.Bump {
+	bump := model.BumpNone
+	for _, change := range r.Changes {
+		if change.Incompatible {
+			return model.BumpMajor
+		}
+		bump = model.BumpMinor
+	}
+	return bump
+}
+
+func (r *Report) Incompatible() []Change {
+	var changes []Change
+	for _, change := range r.Changes {
+		if change.Incompatible {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (r *Report) add(pkg, name string, incompatible bool, format string, args ...any) {
+	r.Changes = append(r.Changes, Change{Package: pkg, Name: name, Incompatible: incompatible, Message: fmt.Sprintf(format, args...)})
+}
+
+// Compare compares the exported API of the module in dir of the old and the
+// new tree. Packages named internal or below internal are not compared.
+func Compare(oldTree, newTree *object.Tree, dir string) (*Report, error) {
+	dir = path.Clean(dir)
+	fset := token.NewFileSet()
+	std := newStdImporter(fset)
+	oldPackages, err := loadPackages(fset, std, oldTree, dir)
+	if err != nil {
+		return nil, err
+	}
+	newPackages, err := loadPackages(fset, std, newTree, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, pkgDir := range sortedKeys(oldPackages, newPackages) {
+		oldPkg, inOld := oldPackages[pkgDir]
+		newPkg, inNew := newPackages[pkgDir]
+		switch {
+		case !inNew:
+			report.add(pkgDir, "", true, "package removed")
+		case !inOld:
+			report.add(pkgDir, "", false, "package added")
+		default:
+			(&comparer{report: report, pkg: pkgDir, old: oldPkg, new: newPkg}).comparePackages()
+		}
+	}
+	return report, nil
+}
+
+// relativeTo returns a qualifier that omits the package itself.
+func relativeTo(pkg *types.Package, qualifier types.Qualifier) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return qualifier(other)
+	}
+}
+
+type loadedPackage struct {
+	pkg       *types.Package
+	qualifier types.Qualifier
+}
+
+func loadPackages(fset *token.FileSet, std types.Importer, tree *object.Tree, dir string) (map[string]loadedPackage, error) {
+	m, err := loadModule(tree, dir)
+	if err != nil {
+		return nil, err
+	}
+	// types of the module are qualified by their module relative directory, so
+	// they compare equal even if the module path changes with a major version
+	qualifier := func(pkg *types.Package) string {
+		if pkgDir, ok := m.packageDir(pkg.Path()); ok {
+			return pkgDir
+		}
+		return pkg.Path()
+	}
+
+	c := newChecker(fset, m, std)
+	packages := map[string]loadedPackage{}
+	for pkgDir := range m.Files {
+		if isInternal(pkgDir) {
+			continue
+		}
+		pkg, err := c.check(pkgDir)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil {
+			packages[pkgDir] = loadedPackage{pkg: pkg, qualifier: qualifier}
+		}
+	}
+	return packages, nil
+}
+
+func isInternal(dir string) bool {
+	for _, segment := range strings.Split(dir, "/") {
+		if segment == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(a, b map[string]loadedPackage) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type comparer struct {
+	report   *Report
+	pkg      string
+	old, new loadedPackage
+}
+
+func (c *comparer) oldString(t types.Type) string {
+	return types.TypeString(t, relativeTo(c.old.pkg, c.old.qualifier))
+}
+
+func (c *comparer) newString(t types.Type) string {
+	return types.TypeString(t, relativeTo(c.new.pkg, c.new.qualifier))
+}
+
+func (c *comparer) comparePackages() {
+	oldScope, newScope := c.old.pkg.Scope(), c.new.pkg.Scope()
+	names := map[string]bool{}
+	for _, name := range append(oldScope.Names(), newScope.Names()...) {
+		if token.IsExported(name) {
+			names[name] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		oldObj, newObj := oldScope.Lookup(name), newScope.Lookup(name)
+		switch {
+		case newObj == nil:
+			c.report.add(c.pkg, name, true, "removed")
+		case oldObj == nil:
+			c.report.add(c.pkg, name, false, "added")
+		default:
+			c.compareObjects(name, oldObj, newObj)
+		}
+	}
+}
+
+func (c *comparer) compareObjects(name string, oldObj, newObj types.Object) {
+	if kind(oldObj) != kind(newObj) {
+		c.report.add(c.pkg, name, true, "changed from %s to %s", kind(oldObj), kind(newObj))
+		return
+	}
+	oldTypeName, ok := oldObj.(*types.TypeName)
+	if !ok {
+		if oldType, newType := c.oldString(oldObj.Type()), c.newString(newObj.Type()); oldType != newType {
+			c.report.add(c.pkg, name, true, "changed type from %s to %s", oldType, newType)
+		}
+		return
+	}
+	newTypeName := newObj.(*types.TypeName)
+
+	oldNamed, oldIsNamed := oldTypeName.Type().(*types.Named)
+	newNamed, newIsNamed := newTypeName.Type().(*types.Named)
+	if oldTypeName.IsAlias() || newTypeName.IsAlias() || !oldIsNamed || !newIsNamed {
+		if oldType, newType := c.oldString(oldTypeName.Type()), c.newString(newTypeName.Type()); oldType != newType || oldTypeName.IsAlias() != newTypeName.IsAlias() {
+			c.report.add(c.pkg, name, true, "changed type from %s to %s", oldType, newType)
+		}
+		return
+	}
+	if oldParams, newParams := c.typeParams(oldNamed.TypeParams(), c.oldString), c.typeParams(newNamed.TypeParams(), c.newString); oldParams != newParams {
+		c.report.add(c.pkg, name, true, "changed type parameters from [%s] to [%s]", oldParams, newParams)
+		return
+	}
+
+	switch oldUnderlying := oldNamed.Underlying().(type) {
+	case *types.Struct:
+		if newUnderlying, ok := newNamed.Underlying().(*types.Struct); ok {
+			c.compareStructs(name, oldUnderlying, newUnderlying)
+			c.compareMethods(name, oldNamed, newNamed)
+			return
+		}
+	case *types.Interface:
+		if newUnderlying, ok := newNamed.Underlying().(*types.Interface); ok {
+			c.compareInterfaces(name, oldUnderlying, newUnderlying)
+			return
+		}
+	default:
+		if oldType, newType := c.oldString(oldUnderlying), c.newString(newNamed.Underlying()); oldType == newType {
+			c.compareMethods(name, oldNamed, newNamed)
+			return
+		}
+	}
+	c.report.add(c.pkg, name, true, "changed underlying type from %s to %s", c.oldString(oldNamed.Underlying()), c.newString(newNamed.Underlying()))
+}
+
+func (c *comparer) typeParams(params *types.TypeParamList, typeString func(types.Type) string) string {
+	var list []string
+	for i := 0; i < params.Len(); i++ {
+		list = append(list, typeString(params.At(i).Constraint()))
+	}
+	return strings.Join(list, ", ")
+}
+
+func (c *comparer) compareStructs(name string, oldStruct, newStruct *types.Struct) {
+	newFields := map[string]*types.Var{}
+	for i := 0; i < newStruct.NumFields(); i++ {
+		if field := newStruct.Field(i); field.Exported() {
+			newFields[field.Name()] = field
+		}
+	}
+	for i := 0; i < oldStruct.NumFields(); i++ {
+		oldField := oldStruct.Field(i)
+		if !oldField.Exported() {
+			continue
+		}
+		newField, ok := newFields[oldField.Name()]
+		delete(newFields, oldField.Name())
+		if !ok {
+			c.report.add(c.pkg, name+"."+oldField.Name(), true, "field removed")
+		} else if oldType, newType := c.oldString(oldField.Type()), c.newString(newField.Type()); oldType != newType {
+			c.report.add(c.pkg, name+"."+oldField.Name(), true, "changed field type from %s to %s", oldType, newType)
+		}
+	}
+	for i := 0; i < newStruct.NumFields(); i++ {
+		if field := newStruct.Field(i); newFields[field.Name()] != nil {
+			c.report.add(c.pkg, name+"."+field.Name(), false, "field added")
+		}
+	}
+}
+
+// compareInterfaces treats every change of the method set as incompatible,
+// as adding a method breaks implementations, unless the interface has
+// unexported methods and cannot be implemented by other packages.
+func (c *comparer) compareInterfaces(name string, oldInterface, newInterface *types.Interface) {
+	sealed := false
+	for i := 0; i < oldInterface.NumMethods(); i++ {
+		if !oldInterface.Method(i).Exported() {
+			sealed = true
+		}
+	}
+	oldMethods, newMethods := methodMap(oldInterface.NumMethods(), oldInterface.Method), methodMap(newInterface.NumMethods(), newInterface.Method)
+	c.compareMethodMaps(name, oldMethods, newMethods, !sealed)
+}
+
+func (c *comparer) compareMethods(name string, oldNamed, newNamed *types.Named) {
+	oldSet, newSet := types.NewMethodSet(types.NewPointer(oldNamed)), types.NewMethodSet(types.NewPointer(newNamed))
+	oldMethods := methodMap(oldSet.Len(), func(i int) *types.Func { return oldSet.At(i).Obj().(*types.Func) })
+	newMethods := methodMap(newSet.Len(), func(i int) *types.Func { return newSet.At(i).Obj().(*types.Func) })
+	c.compareMethodMaps(name, oldMethods, newMethods, false)
+}
+
+func (c *comparer) compareMethodMaps(name string, oldMethods, newMethods map[string]*types.Func, additionsIncompatible bool) {
+	for _, method := range sortedMethodNames(oldMethods) {
+		newMethod, ok := newMethods[method]
+		if !ok {
+			c.report.add(c.pkg, name+"."+method, true, "method removed")
+		} else if oldType, newType := c.oldString(oldMethods[method].Type()), c.newString(newMethod.Type()); oldType != newType {
+			c.report.add(c.pkg, name+"."+method, true, "changed method signature from %s to %s", oldType, newType)
+		}
+	}
+	for _, method := range sortedMethodNames(newMethods) {
+		if _, ok := oldMethods[method]; !ok {
+			c.report.add(c.pkg, name+"."+method, additionsIncompatible, "method added")
+		}
+	}
+}
+
+func methodMap(n int, at func(int) *types.Func) map[string]*types.Func {
+	methods := map[string]*types.Func{}
+	for i := 0; i < n; i++ {
+		if method := at(i); method.Exported() {
+			methods[method.Name()] = method
+		}
+	}
+	return methods
+}
+
+func sortedMethodNames(methods map[string]*types.Func) []string {
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func kind(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Const:
+		return "constant"
+	case *types.Var:
+		return "variable"
+	case *types.Func:
+		return "function"
+	case *types.TypeName:
+		return "type"
+	}
+	return "object"
+}
@@ -0,0 +1,187 @@
+package apidiff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitTree commits the files, given as name and content pairs, to a new
+// repository and returns the tree of the commit.
+func commitTree(t *testing.T, files map[string]string) *object.Tree {
+	t.Helper()
+
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	for name, content := range files {
+		f, err := fs.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		_, err = wt.Add(name)
+		assert.NoError(t, err)
+	}
+	hash, err := wt.Commit("commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test Bot", Email: "test@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+	commit, err := repo.CommitObject(hash)
+	assert.NoError(t, err)
+	tree, err := commit.Tree()
+	assert.NoError(t, err)
+	return tree
+}
+
+const oldAPI = `package x
+
+import "io"
+
+const Version = "1"
+
+var Default = Config{}
+
+type Config struct {
+	Name    string
+	Timeout int
+	secret  string
+}
+
+func (c *Config) Validate() error { return nil }
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type ID int
+
+func New(name string) *Config { return &Config{Name: name} }
+
+func Copy(w io.Writer, r io.Reader) error { return nil }
+
+func unexported() {}
+`
+
+func TestCompare_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{"go.mod": "module example.com/x\n", "x.go": oldAPI}
+	report, err := Compare(commitTree(t, files), commitTree(t, files), ".")
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Changes)
+	assert.Equal(t, model.BumpNone, report.Bump())
+}
+
+func TestCompare_CompatibleChanges(t *testing.T) {
+	t.Parallel()
+
+	oldTree := commitTree(t, map[string]string{"go.mod": "module example.com/x\n", "x.go": oldAPI})
+	newTree := commitTree(t, map[string]string{
+		"go.mod": "module example.com/x\n",
+		"x.go": oldAPI + `
+func (c Config) String() string { return c.Name }
+
+func Extra() {}
+`,
+		"y.go":          "package x\n\ntype Options struct{ Debug bool }\n",
+		"sub/sub.go":    "package sub\n\nfunc Helper() {}\n",
+		"internal/i.go": "package internal\n\nfunc Hidden() {}\n",
+		"x_test.go":     "package x\n\nfunc TestOnly() {}\n",
+	})
+	report, err := Compare(oldTree, newTree, ".")
+
+	assert.NoError(t, err)
+	messages := []string{}
+	for _, change := range report.Changes {
+		messages = append(messages, change.String())
+	}
+	assert.Equal(t, []string{
+		"Config.String: method added",
+		"Extra: added",
+		"Options: added",
+		"sub: package added",
+	}, messages)
+	assert.Equal(t, model.BumpMinor, report.Bump())
+}
+
+func TestCompare_IncompatibleChanges(t *testing.T) {
+	t.Parallel()
+
+	oldTree := commitTree(t, map[string]string{"go.mod": "module example.com/x\n", "x.go": oldAPI, "sub/sub.go": "package sub\n"})
+	newTree := commitTree(t, map[string]string{"go.mod": "module example.com/x/v2\n", "x.go": `package x
+
+import "io"
+
+const Version = 1
+
+var Default = &Config{}
+
+type Config struct {
+	Name    string
+	Timeout int64
+	Retries int
+}
+
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
+
+type ID string
+
+func New(name string, opts ...string) *Config { return &Config{Name: name} }
+
+func Copy(w io.Writer, r io.Reader) error { return nil }
+`})
+	report, err := Compare(oldTree, newTree, ".")
+
+	assert.NoError(t, err)
+	messages := []string{}
+	for _, change := range report.Incompatible() {
+		messages = append(messages, change.String())
+	}
+	assert.Equal(t, []string{
+		"Config.Timeout: changed field type from int to int64",
+		"Config.Validate: method removed",
+		"Default: changed type from Config to *Config",
+		"ID: changed underlying type from int to string",
+		"New: changed type from func(name string) *Config to func(name string, opts ...string) *Config",
+		"Store.Set: method added",
+		"Version: changed type from untyped string to untyped int",
+		"sub: package removed",
+	}, messages)
+	assert.Equal(t, model.BumpMajor, report.Bump())
+}
+
+func TestCompare_NestedModule(t *testing.T) {
+	t.Parallel()
+
+	oldTree := commitTree(t, map[string]string{
+		"go.mod":         "module example.com/x\n",
+		"api/go.mod":     "module example.com/x/api\n",
+		"api/api.go":     "package api\n\nimport \"example.com/x/api/types\"\n\nfunc Get() types.Item { return types.Item{} }\n",
+		"api/types/t.go": "package types\n\ntype Item struct{ ID int }\n",
+	})
+	newTree := commitTree(t, map[string]string{
+		"go.mod":         "module example.com/x\n",
+		"api/go.mod":     "module example.com/x/api\n",
+		"api/api.go":     "package api\n\nimport \"example.com/x/api/types\"\n\nfunc Get() types.Item { return types.Item{} }\n",
+		"api/types/t.go": "package types\n\ntype Item struct{ ID string }\n",
+		"root.go":        "package x\n\nfunc Root() {}\n",
+	})
+	report, err := Compare(oldTree, newTree, "api")
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Changes, 1)
+	assert.Equal(t, "types.Item.ID: changed field type from int to string", report.Changes[0].String())
+}
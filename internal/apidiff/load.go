@@ -0,0 +1,217 @@
+package apidiff
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/gomod"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// module holds the Go files of the packages of a module read from a git tree.
+type module struct {
+	Path string
+	// Files maps module relative package directories to the file names and
+	// contents of the package.
+	Files map[string]map[string][]byte
+}
+
+func (m *module) importPath(dir string) string {
+	if dir == "." {
+		return m.Path
+	}
+	return m.Path + "/" + dir
+}
+
+func (m *module) packageDir(importPath string) (string, bool) {
+	if importPath == m.Path {
+		return ".", true
+	}
+	if dir, ok := strings.CutPrefix(importPath, m.Path+"/"); ok {
+		return dir, true
+	}
+	return "", false
+}
+
+// loadModule reads the module in dir of the tree, without test files, testdata
+// and vendor directories and nested modules.
+func loadModule(tree *object.Tree, dir string) (*module, error) {
+	goModFile, err := tree.File(path.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Join(dir, "go.mod"), err)
+	}
+	content, err := goModFile.Contents()
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := gomod.Parse([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Join(dir, "go.mod"), err)
+	}
+
+	type file struct {
+		dir, name string
+		f         *object.File
+	}
+	var files []file
+	nested := map[string]bool{}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		rel := f.Name
+		if dir != "." {
+			var ok bool
+			if rel, ok = strings.CutPrefix(f.Name, dir+"/"); !ok {
+				return nil
+			}
+		}
+		fileDir, name := path.Split(rel)
+		fileDir = path.Clean(fileDir)
+		if name == "go.mod" && fileDir != "." {
+			nested[fileDir] = true
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || ignoredDir(fileDir) {
+			return nil
+		}
+		files = append(files, file{dir: fileDir, name: name, f: f})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := &module{Path: goMod.Module, Files: map[string]map[string][]byte{}}
+	for _, file := range files {
+		if inNestedModule(file.dir, nested) {
+			continue
+		}
+		content, err := file.f.Contents()
+		if err != nil {
+			return nil, err
+		}
+		if m.Files[file.dir] == nil {
+			m.Files[file.dir] = map[string][]byte{}
+		}
+		m.Files[file.dir][file.name] = []byte(content)
+	}
+	return m, nil
+}
+
+func ignoredDir(dir string) bool {
+	for _, segment := range strings.Split(dir, "/") {
+		if segment == "vendor" || segment == "testdata" || strings.HasPrefix(segment, ".") && segment != "." || strings.HasPrefix(segment, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+func inNestedModule(dir string, nested map[string]bool) bool {
+	for ; dir != "."; dir = path.Dir(dir) {
+		if nested[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// checker type-checks the packages of a module. Packages of the standard
+// library are imported from source, other imports are not resolved and
+// result in invalid types, which compare equal on both sides.
+type checker struct {
+	fset     *token.FileSet
+	module   *module
+	std      types.Importer
+	packages map[string]*types.Package
+}
+
+func newChecker(fset *token.FileSet, m *module, std types.Importer) *checker {
+	return &checker{fset: fset, module: m, std: std, packages: map[string]*types.Package{}}
+}
+
+func (c *checker) Import(importPath string) (*types.Package, error) {
+	if dir, ok := c.module.packageDir(importPath); ok {
+		if _, ok := c.module.Files[dir]; ok {
+			return c.check(dir)
+		}
+	}
+	if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") {
+		return c.std.Import(importPath)
+	}
+	return nil, fmt.Errorf("package %s is not part of the module", importPath)
+}
+
+// check type-checks the package in the module relative directory. It returns
+// nil for directories without an importable package.
+func (c *checker) check(dir string) (*types.Package, error) {
+	importPath := c.module.importPath(dir)
+	if pkg, ok := c.packages[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle or invalid package %s", importPath)
+		}
+		return pkg, nil
+	}
+	c.packages[importPath] = nil
+
+	files, err := c.parse(dir)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	config := types.Config{
+		Importer:         c,
+		IgnoreFuncBodies: true,
+		FakeImportC:      true,
+		Error:            func(error) {},
+	}
+	pkg, _ := config.Check(importPath, c.fset, files, nil)
+	c.packages[importPath] = pkg
+	return pkg, nil
+}
+
+func (c *checker) parse(dir string) ([]*ast.File, error) {
+	sources := c.module.Files[dir]
+	ctxt := build.Default
+	ctxt.OpenFile = func(name string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(sources[path.Base(name)])), nil
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var files []*ast.File
+	packageName := ""
+	for _, name := range names {
+		if ok, err := ctxt.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, path.Join(c.module.importPath(dir), name), sources[name], parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if packageName == "" {
+			packageName = file.Name.Name
+		}
+		if file.Name.Name != packageName || file.Name.Name == "main" {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// newStdImporter returns the importer for the standard library.
+func newStdImporter(fset *token.FileSet) types.Importer {
+	return importer.ForCompiler(fset, "source", nil)
+}
@@ -0,0 +1,103 @@
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/StevenCyb/autosemver/internal/generator"
+)
+
+type section struct {
+	Title string
+	Types []string
+}
+
+var sections = []section{
+	{Title: "Features", Types: []string{"feat"}},
+	{Title: "Bug Fixes", Types: []string{"fix"}},
+	{Title: "Performance", Types: []string{"perf"}},
+}
+
+func Render(w io.Writer, releases []generator.Release) error {
+	for i, release := range releases {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := renderRelease(w, release); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderRelease(w io.Writer, release generator.Release) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s (%s)\n", release.Tag, release.Date.Format("2006-01-02"))
+
+	var breaking []string
+	for _, commit := range release.Commits {
+		if commit.Message == nil || commit.Cancelled || !commit.Message.IsBreaking() {
+			continue
+		}
+		note, ok := commit.Message.Footer(conventional.BreakingChangeToken)
+		if !ok {
+			note, ok = commit.Message.Footer(conventional.BreakingChangeTokenHyphens)
+		}
+		if !ok {
+			note = commit.Message.Description
+		}
+		breaking = append(breaking, entry(commit, note))
+	}
+	writeSection(&b, "Breaking Changes", breaking)
+
+	for _, section := range sections {
+		var entries []string
+		for _, commit := range release.Commits {
+			if commit.Message != nil && !commit.Cancelled && containsFold(section.Types, commit.Message.Type) {
+				entries = append(entries, entry(commit, commit.Message.Description))
+			}
+		}
+		writeSection(&b, section.Title, entries)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeSection(b *strings.Builder, title string, entries []string) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	for _, entry := range entries {
+		b.WriteString(entry)
+	}
+}
+
+func entry(commit generator.Commit, text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	if commit.Message.Scope != "" {
+		return fmt.Sprintf("* **%s:** %s (%s)\n", commit.Message.Scope, text, shortHash(commit.Hash))
+	}
+	return fmt.Sprintf("* %s (%s)\n", text, shortHash(commit.Hash))
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func commit(t *testing.T, hash, message string) generator.Commit {
+	t.Helper()
+
+	msg, _ := conventional.Parse(message)
+	return generator.Commit{Hash: hash, Message: msg}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	releases := []generator.Release{
+		{
+			Tag:     "v1.1.0",
+			Version: semver.MustParse("1.1.0"),
+			Date:    time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+			Commits: []generator.Commit{
+				commit(t, "aaaaaaaaaa", "feat(api): add endpoint"),
+				commit(t, "bbbbbbbbbb", "fix: fix a bug"),
+				commit(t, "cccccccccc", "chore: update deps"),
+				commit(t, "dddddddddd", "not conventional"),
+				commit(t, "eeeeeeeeee", "perf(db): faster queries"),
+				commit(t, "ffffffffff", "refactor!: rename config\n\nBREAKING CHANGE: the config key\nfoo is now bar"),
+			},
+		},
+		{
+			Tag:     "v1.0.0",
+			Version: semver.MustParse("1.0.0"),
+			Date:    time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC),
+			Commits: []generator.Commit{
+				commit(t, "1111111111", "feat!: first release"),
+			},
+		},
+	}
+	var b bytes.Buffer
+	err := Render(&b, releases)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `## v1.1.0 (2024-05-02)
+
+### Breaking Changes
+
+* the config key foo is now bar (fffffff)
+
+### Features
+
+* **api:** add endpoint (aaaaaaa)
+
+### Bug Fixes
+
+* fix a bug (bbbbbbb)
+
+### Performance
+
+* **db:** faster queries (eeeeeee)
+
+## v1.0.0 (2024-04-01)
+
+### Breaking Changes
+
+* first release (1111111)
+
+### Features
+
+* first release (1111111)
+`, b.String())
+}
+
+func TestRender_NoReleases(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Render(&b, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", b.String())
+}
+
+func TestRender_SkipsCancelledCommits(t *testing.T) {
+	t.Parallel()
+
+	reverted := commit(t, "aaaaaaaaaa", "feat!: add endpoint")
+	reverted.Cancelled = true
+	revert := commit(t, "bbbbbbbbbb", "revert: add endpoint\n\nRefs: aaaaaaa")
+	revert.Cancelled = true
+	var b bytes.Buffer
+	err := Render(&b, []generator.Release{{
+		Tag:     "v1.0.1",
+		Version: semver.MustParse("1.0.1"),
+		Date:    time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		Commits: []generator.Commit{revert, reverted, commit(t, "cccccccccc", "fix: fix a bug")},
+	}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `## v1.0.1 (2024-05-02)
+
+### Bug Fixes
+
+* fix a bug (ccccccc)
+`, b.String())
+}
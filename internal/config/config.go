@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/StevenCyb/autosemver/internal/lint"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+var FileNames = []string{".autosemver.yaml", ".autosemver.yml"}
+
+type Config struct {
+	Mappings          []model.Tuple[string, model.Bump]
+	TagFormat         model.TagFormat
+	PreRelease        string
+	IgnoreInvalidTags bool
+	DisableExit1      bool
+	Verbose           bool
+	BranchRules       []model.BranchRule
+	Output            model.OutputFormat
+	Components        []model.Component
+	// DiscoverDependencies derives dependencies between components from Go
+	// modules in addition to the declared ones.
+	DiscoverDependencies bool
+	GoModuleCheck        model.CheckLevel
+	APICheck             model.CheckLevel
+	// LintTypes are the commit types allowed by the lint command in addition
+	// to the types of the mappings.
+	LintTypes []string
+	// InitialDevelopment lowers bumps while the major version is 0.
+	InitialDevelopment bool
+	MergeStrategy      model.MergeStrategy
+	// SquashCommits evaluates every commit listed in a commit body.
+	SquashCommits bool
+	FirstParent   bool
+}
+
+func Default() *Config {
+	return &Config{
+		Mappings: []model.Tuple[string, model.Bump]{
+			{First: "feat", Second: model.BumpMinor},
+			{First: "perf", Second: model.BumpPatch},
+			{First: "fix", Second: model.BumpPatch},
+		},
+		Output:        model.OutputText,
+		MergeStrategy: model.MergeMessage,
+		GoModuleCheck: model.CheckError,
+		APICheck:      model.CheckOff,
+		LintTypes:     lint.DefaultTypes,
+	}
+}
+
+// Find returns the path of the configuration file in the given directory.
+func Find(dir string) (string, bool) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Load applies the settings of the configuration file to cfg. Settings that
+// are not present in the file are left untouched.
+func Load(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := Parse(data, cfg); err != nil {
+		var validationErrors ValidationErrors
+		if errors.As(err, &validationErrors) {
+			validationErrors.File = path
+			return validationErrors
+		}
+		return err
+	}
+	return nil
+}
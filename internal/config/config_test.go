@@ -0,0 +1,193 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	err := Parse([]byte(`
+mappings:
+  feat: minor
+  fix: patch
+  refactor: patch
+tagFormat: v{version}
+preRelease: beta
+ignoreInvalidTags: true
+disableExit1: true
+verbose: false
+branchRules:
+  - pattern: release/*
+  - pattern: hotfix
+    range: 1.x
+output: json
+components:
+  - name: svc-a
+    paths: [services/a]
+    exclude: [services/a/testdata]
+  - name: libs
+    paths: [libs/**, go.mod]
+    tagFormat: libs-{version}
+    dependsOn: [svc-a]
+discoverDependencies: true
+goModuleCheck: warn
+apiCheck: error
+lintTypes: [chore, docs]
+initialDevelopment: true
+mergeStrategy: title
+squashCommits: true
+firstParent: true
+`), cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Tuple[string, model.Bump]{
+		{First: "feat", Second: model.BumpMinor},
+		{First: "fix", Second: model.BumpPatch},
+		{First: "refactor", Second: model.BumpPatch},
+	}, cfg.Mappings)
+	assert.Equal(t, model.TagFormat{Prefix: "v"}, cfg.TagFormat)
+	assert.Equal(t, "beta", cfg.PreRelease)
+	assert.True(t, cfg.IgnoreInvalidTags)
+	assert.True(t, cfg.DisableExit1)
+	assert.False(t, cfg.Verbose)
+	assert.Equal(t, []model.BranchRule{{Pattern: "release/*"}, {Pattern: "hotfix", Range: "1.x"}}, cfg.BranchRules)
+	assert.Equal(t, model.OutputJSON, cfg.Output)
+	assert.Equal(t, []model.Component{
+		{Name: "svc-a", Paths: []string{"services/a"}, Exclude: []string{"services/a/testdata"}, TagFormat: model.TagFormat{Prefix: "svc-a/v"}},
+		{Name: "libs", Paths: []string{"libs/**", "go.mod"}, TagFormat: model.TagFormat{Prefix: "libs-"}, DependsOn: []string{"svc-a"}},
+	}, cfg.Components)
+	assert.True(t, cfg.DiscoverDependencies)
+	assert.Equal(t, model.CheckWarn, cfg.GoModuleCheck)
+	assert.Equal(t, model.CheckError, cfg.APICheck)
+	assert.Equal(t, []string{"chore", "docs"}, cfg.LintTypes)
+	assert.True(t, cfg.InitialDevelopment)
+	assert.Equal(t, model.MergeTitle, cfg.MergeStrategy)
+	assert.True(t, cfg.SquashCommits)
+	assert.True(t, cfg.FirstParent)
+}
+
+func TestParse_InvalidComponents(t *testing.T) {
+	t.Parallel()
+
+	err := Parse([]byte(`components:
+  - name: svc-a
+    paths: [services/a]
+  - name: svc-a
+    paths: [services/b]
+  - name: svc b
+    paths: [services/b]
+  - name: svc-c
+  - name: svc-d
+    paths: [services/d]
+    tagFormat: svc-d
+  - name: svc-e
+    paths: [services/e]
+    dependsOn: [svc-x]
+  - name: svc-f
+    paths: [services/f]
+    dependsOn: [svc-f]
+`), Default())
+
+	var validationErrors ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	lines := []int{}
+	for _, e := range validationErrors.Errors {
+		lines = append(lines, e.Line)
+	}
+	assert.Equal(t, []int{4, 6, 8, 9, 15, 12}, lines)
+	assert.Contains(t, err.Error(), "line 4: duplicate component name 'svc-a'")
+	assert.Contains(t, err.Error(), "line 12: component svc-e depends on unknown component 'svc-x'")
+}
+
+func TestParse_KeepsDefaultsOfMissingKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	err := Parse([]byte("tagFormat: v{version}\n"), cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Default().Mappings, cfg.Mappings)
+	assert.Equal(t, model.OutputText, cfg.Output)
+	assert.Equal(t, model.CheckError, cfg.GoModuleCheck)
+}
+
+func TestParse_Empty(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	err := Parse([]byte(""), cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestParse_ValidationErrorsWithLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	err := Parse([]byte(`mappings:
+  feat: minr
+tagFormat: v
+ignoreInvalidTags: maybe
+unknown: 1
+branchRules:
+  - pattern: release/*
+    range: latest
+  - patern: x
+output: yaml
+preRelease: rc.1
+mergeStrategy: rebase
+`), Default())
+
+	var validationErrors ValidationErrors
+	assert.ErrorAs(t, err, &validationErrors)
+	lines := []int{}
+	for _, e := range validationErrors.Errors {
+		lines = append(lines, e.Line)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 7, 9, 9, 10, 11, 12}, lines)
+	assert.Contains(t, err.Error(), "line 2: invalid mapping for 'feat'")
+	assert.Contains(t, err.Error(), "line 5: unknown key 'unknown'")
+	assert.Contains(t, err.Error(), "line 12: invalid merge strategy 'rebase'")
+}
+
+func TestParse_NotAMapping(t *testing.T) {
+	t.Parallel()
+
+	err := Parse([]byte("- a\n- b\n"), Default())
+
+	assert.ErrorContains(t, err, "line 1: configuration must be a mapping")
+}
+
+func TestParse_InvalidYAML(t *testing.T) {
+	t.Parallel()
+
+	err := Parse([]byte("mappings: [\n"), Default())
+
+	assert.Error(t, err)
+}
+
+func TestFindAndLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, ok := Find(dir)
+	assert.False(t, ok)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".autosemver.yml"), []byte("output: env\nverbose: yes\n"), 0o644))
+	path, ok := Find(dir)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, ".autosemver.yml"), path)
+
+	cfg := Default()
+	err := Load(path, cfg)
+
+	assert.ErrorContains(t, err, path+":2: expected a boolean")
+	assert.Equal(t, model.OutputEnv, cfg.Output)
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ValidationError struct {
+	Line    int
+	Message string
+}
+
+type ValidationErrors struct {
+	File   string
+	Errors []ValidationError
+}
+
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if e.File != "" {
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", e.File, err.Line, err.Message))
+		} else {
+			lines = append(lines, fmt.Sprintf("line %d: %s", err.Line, err.Message))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type parser struct {
+	errors []ValidationError
+}
+
+func (p *parser) fail(node *yaml.Node, format string, args ...any) {
+	p.errors = append(p.errors, ValidationError{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+// Parse applies the YAML configuration in data to cfg. All schema errors are
+// collected and returned as ValidationErrors.
+func Parse(data []byte, cfg *Config) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	p := &parser{}
+	document := root.Content[0]
+	if document.Kind != yaml.MappingNode {
+		p.fail(document, "configuration must be a mapping")
+		return ValidationErrors{Errors: p.errors}
+	}
+	for i := 0; i+1 < len(document.Content); i += 2 {
+		key, value := document.Content[i], document.Content[i+1]
+		switch key.Value {
+		case "mappings":
+			p.parseMappings(value, cfg)
+		case "tagFormat":
+			if s, ok := p.string(value); ok {
+				tagFormat, err := model.ParseTagFormat(s)
+				if err != nil {
+					p.fail(value, "%s", err)
+				}
+				cfg.TagFormat = tagFormat
+			}
+		case "preRelease":
+			if s, ok := p.string(value); ok {
+				if err := model.ValidateChannel(s); err != nil {
+					p.fail(value, "%s", err)
+				}
+				cfg.PreRelease = s
+			}
+		case "ignoreInvalidTags":
+			p.bool(value, &cfg.IgnoreInvalidTags)
+		case "disableExit1":
+			p.bool(value, &cfg.DisableExit1)
+		case "verbose":
+			p.bool(value, &cfg.Verbose)
+		case "branchRules":
+			p.parseBranchRules(value, cfg)
+		case "output":
+			if s, ok := p.string(value); ok {
+				format, err := model.ParseOutputFormat(s)
+				if err != nil {
+					p.fail(value, "%s", err)
+				}
+				cfg.Output = format
+			}
+		case "components":
+			p.parseComponents(value, cfg)
+		case "discoverDependencies":
+			p.bool(value, &cfg.DiscoverDependencies)
+		case "goModuleCheck":
+			p.checkLevel(value, &cfg.GoModuleCheck)
+		case "apiCheck":
+			p.checkLevel(value, &cfg.APICheck)
+		case "lintTypes":
+			cfg.LintTypes = p.strings(value)
+		case "initialDevelopment":
+			p.bool(value, &cfg.InitialDevelopment)
+		case "mergeStrategy":
+			if s, ok := p.string(value); ok {
+				strategy, err := model.ParseMergeStrategy(s)
+				if err != nil {
+					p.fail(value, "%s", err)
+				} else {
+					cfg.MergeStrategy = strategy
+				}
+			}
+		case "squashCommits":
+			p.bool(value, &cfg.SquashCommits)
+		case "firstParent":
+			p.bool(value, &cfg.FirstParent)
+		default:
+			p.fail(key, "unknown key '%s'", key.Value)
+		}
+	}
+
+	if len(p.errors) > 0 {
+		return ValidationErrors{Errors: p.errors}
+	}
+	return nil
+}
+
+func (p *parser) parseMappings(node *yaml.Node, cfg *Config) {
+	if node.Kind != yaml.MappingNode {
+		p.fail(node, "mappings must be a mapping of commit types to {major, minor, patch}")
+		return
+	}
+	mappings := []model.Tuple[string, model.Bump]{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		commitType, ok := p.string(key)
+		if !ok {
+			continue
+		}
+		if s, ok := p.string(value); ok {
+			bump, err := model.ParseBump(s)
+			if err != nil {
+				p.fail(value, "invalid mapping for '%s': %s", commitType, err)
+				continue
+			}
+			mappings = append(mappings, model.Tuple[string, model.Bump]{First: commitType, Second: bump})
+		}
+	}
+	cfg.Mappings = mappings
+}
+
+func (p *parser) parseBranchRules(node *yaml.Node, cfg *Config) {
+	if node.Kind != yaml.SequenceNode {
+		p.fail(node, "branchRules must be a list")
+		return
+	}
+	rules := []model.BranchRule{}
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			p.fail(item, "branch rule must be a mapping with 'pattern' and optional 'range'")
+			continue
+		}
+		var rule model.BranchRule
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			switch key.Value {
+			case "pattern":
+				rule.Pattern, _ = p.string(value)
+			case "range":
+				rule.Range, _ = p.string(value)
+			default:
+				p.fail(key, "unknown key '%s' in branch rule", key.Value)
+			}
+		}
+		if err := rule.Validate(); err != nil {
+			p.fail(item, "%s", err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	cfg.BranchRules = rules
+}
+
+func (p *parser) parseComponents(node *yaml.Node, cfg *Config) {
+	if node.Kind != yaml.SequenceNode {
+		p.fail(node, "components must be a list")
+		return
+	}
+	components := []model.Component{}
+	names := map[string]bool{}
+	dependencyNodes := map[string]*yaml.Node{}
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			p.fail(item, "component must be a mapping with 'name', 'paths' and optional 'tagFormat'")
+			continue
+		}
+		var component model.Component
+		tagFormat := ""
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			switch key.Value {
+			case "name":
+				component.Name, _ = p.string(value)
+			case "paths":
+				component.Paths = p.strings(value)
+			case "exclude":
+				component.Exclude = p.strings(value)
+			case "tagFormat":
+				tagFormat, _ = p.string(value)
+			case "dependsOn":
+				component.DependsOn = p.strings(value)
+			default:
+				p.fail(key, "unknown key '%s' in component", key.Value)
+			}
+		}
+		if err := component.Validate(); err != nil {
+			p.fail(item, "%s", err)
+			continue
+		}
+		if names[component.Name] {
+			p.fail(item, "duplicate component name '%s'", component.Name)
+			continue
+		}
+		names[component.Name] = true
+		component.TagFormat = model.DefaultComponentTagFormat(component.Name)
+		if tagFormat != "" {
+			var err error
+			if component.TagFormat, err = model.ParseTagFormat(tagFormat); err != nil {
+				p.fail(item, "%s", err)
+				continue
+			}
+		}
+		components = append(components, component)
+		dependencyNodes[component.Name] = item
+	}
+	for _, component := range components {
+		for _, dependency := range component.DependsOn {
+			if !names[dependency] {
+				p.fail(dependencyNodes[component.Name], "component %s depends on unknown component '%s'", component.Name, dependency)
+			}
+		}
+	}
+	cfg.Components = components
+}
+
+func (p *parser) strings(node *yaml.Node) []string {
+	if node.Kind != yaml.SequenceNode {
+		p.fail(node, "expected a list of strings")
+		return nil
+	}
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		if s, ok := p.string(item); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func (p *parser) checkLevel(node *yaml.Node, target *model.CheckLevel) {
+	if s, ok := p.string(node); ok {
+		level, err := model.ParseCheckLevel(s)
+		if err != nil {
+			p.fail(node, "%s", err)
+		}
+		*target = level
+	}
+}
+
+func (p *parser) string(node *yaml.Node) (string, bool) {
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		p.fail(node, "expected a string")
+		return "", false
+	}
+	return node.Value, true
+}
+
+func (p *parser) bool(node *yaml.Node, target *bool) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+		p.fail(node, "expected a boolean")
+		return
+	}
+	*target, _ = strconv.ParseBool(strings.ToLower(node.Value))
+}
@@ -0,0 +1,55 @@
+package conventional
+
+import "strings"
+
+const (
+	BreakingChangeToken        = "BREAKING CHANGE"
+	BreakingChangeTokenHyphens = "BREAKING-CHANGE"
+)
+
+type Footer struct {
+	Token     string
+	Separator string
+	Value     string
+}
+
+type Commit struct {
+	Type           string
+	Scope          string
+	BreakingMarker bool
+	Description    string
+	Body           string
+	Footers        []Footer
+}
+
+func (c *Commit) IsBreaking() bool {
+	if c.BreakingMarker {
+		return true
+	}
+	for _, footer := range c.Footers {
+		if footer.Token == BreakingChangeToken || footer.Token == BreakingChangeTokenHyphens {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Commit) Footer(token string) (string, bool) {
+	for _, footer := range c.Footers {
+		if strings.EqualFold(footer.Token, token) {
+			return footer.Value, true
+		}
+	}
+	return "", false
+}
+
+func (c *Commit) Header() string {
+	header := c.Type
+	if c.Scope != "" {
+		header += "(" + c.Scope + ")"
+	}
+	if c.BreakingMarker {
+		header += "!"
+	}
+	return header + ": " + c.Description
+}
@@ -0,0 +1,129 @@
+package conventional
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEmptyMessage       = errors.New("commit message is empty")
+	ErrMissingSeparator   = errors.New("header is missing the ': ' separator between type and description")
+	ErrInvalidType        = errors.New("header type must be a noun consisting of letters, digits and hyphens")
+	ErrInvalidScope       = errors.New("header scope must be a non-empty noun in parentheses")
+	ErrMissingDescription = errors.New("header description must not be empty")
+	ErrMissingBlankLine   = errors.New("body must begin one blank line after the description")
+)
+
+var typePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+var scopePattern = regexp.MustCompile(`^[^()\s][^()]*$`)
+var footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9][A-Za-z0-9-]*)(: | #)(.*)$`)
+
+func Parse(message string) (*Commit, error) {
+	return parse(message, true)
+}
+
+// ParseLenient is like Parse, but reads the lines following the header as the
+// body even if the blank line between them is missing.
+func ParseLenient(message string) (*Commit, error) {
+	return parse(message, false)
+}
+
+func parse(message string, strict bool) (*Commit, error) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	message = strings.TrimRight(message, " \t\n")
+	if strings.TrimSpace(message) == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	lines := strings.Split(message, "\n")
+	commit, err := parseHeader(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 1 {
+		return commit, nil
+	}
+	rest := lines[1:]
+	if strings.TrimSpace(lines[1]) == "" {
+		rest = lines[2:]
+	} else if strict {
+		return nil, ErrMissingBlankLine
+	}
+
+	footerStart := findFooterStart(rest)
+	commit.Body = strings.Trim(strings.Join(rest[:footerStart], "\n"), "\n")
+	commit.Footers = parseFooters(rest[footerStart:])
+
+	return commit, nil
+}
+
+func parseHeader(header string) (*Commit, error) {
+	separator := strings.Index(header, ":")
+	if separator == -1 {
+		return nil, ErrMissingSeparator
+	}
+	prefix := header[:separator]
+	description := header[separator+1:]
+	if !strings.HasPrefix(description, " ") {
+		return nil, ErrMissingSeparator
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, ErrMissingDescription
+	}
+
+	commit := &Commit{Description: description}
+	if strings.HasSuffix(prefix, "!") {
+		commit.BreakingMarker = true
+		prefix = strings.TrimSuffix(prefix, "!")
+	}
+	if open := strings.Index(prefix, "("); open != -1 {
+		if !strings.HasSuffix(prefix, ")") {
+			return nil, ErrInvalidScope
+		}
+		commit.Scope = prefix[open+1 : len(prefix)-1]
+		if !scopePattern.MatchString(commit.Scope) {
+			return nil, ErrInvalidScope
+		}
+		prefix = prefix[:open]
+	}
+	if !typePattern.MatchString(prefix) {
+		return nil, ErrInvalidType
+	}
+	commit.Type = prefix
+
+	return commit, nil
+}
+
+// findFooterStart returns the index of the first paragraph from which on
+// every paragraph starts with a footer token, or len(lines) if there is none.
+func findFooterStart(lines []string) int {
+	start := len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" && (i == 0 || strings.TrimSpace(lines[i-1]) == "") {
+			if !footerPattern.MatchString(lines[i]) {
+				break
+			}
+			start = i
+		}
+	}
+	return start
+}
+
+func parseFooters(lines []string) []Footer {
+	var footers []Footer
+	for _, line := range lines {
+		if match := footerPattern.FindStringSubmatch(line); match != nil {
+			footers = append(footers, Footer{Token: match[1], Separator: strings.TrimSpace(match[2]), Value: match[3]})
+			continue
+		}
+		if len(footers) > 0 {
+			footers[len(footers)-1].Value += "\n" + line
+		}
+	}
+	for i := range footers {
+		footers[i].Value = strings.TrimSpace(footers[i].Value)
+	}
+	return footers
+}
@@ -0,0 +1,126 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_HeaderOnly(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("feat: add something")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "feat", commit.Type)
+	assert.Equal(t, "", commit.Scope)
+	assert.False(t, commit.BreakingMarker)
+	assert.Equal(t, "add something", commit.Description)
+	assert.Equal(t, "", commit.Body)
+	assert.Empty(t, commit.Footers)
+	assert.False(t, commit.IsBreaking())
+}
+
+func TestParse_ScopeAndBreakingMarker(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("fix(api)!: drop legacy endpoint\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fix", commit.Type)
+	assert.Equal(t, "api", commit.Scope)
+	assert.True(t, commit.BreakingMarker)
+	assert.True(t, commit.IsBreaking())
+	assert.Equal(t, "fix(api)!: drop legacy endpoint", commit.Header())
+}
+
+func TestParse_BodyAndFooters(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("fix: prevent racing of requests\n\nIntroduce a request id.\n\nRemove timeouts which were used\nto mitigate the issue.\n\nReviewed-by: Z\nRefs #123\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Introduce a request id.\n\nRemove timeouts which were used\nto mitigate the issue.", commit.Body)
+	assert.Equal(t, []Footer{
+		{Token: "Reviewed-by", Separator: ":", Value: "Z"},
+		{Token: "Refs", Separator: "#", Value: "123"},
+	}, commit.Footers)
+	value, ok := commit.Footer("reviewed-by")
+	assert.True(t, ok)
+	assert.Equal(t, "Z", value)
+}
+
+func TestParse_BreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("feat: allow config to extend other configs\n\nBREAKING CHANGE: `extends` key in config file is now used\nfor extending other config files")
+
+	assert.NoError(t, err)
+	assert.False(t, commit.BreakingMarker)
+	assert.True(t, commit.IsBreaking())
+	value, ok := commit.Footer(BreakingChangeToken)
+	assert.True(t, ok)
+	assert.Equal(t, "`extends` key in config file is now used\nfor extending other config files", value)
+}
+
+func TestParse_BreakingChangeFooterWithHyphen(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("chore: drop support for Node 6\n\nBREAKING-CHANGE: use JavaScript features not available in Node 6.")
+
+	assert.NoError(t, err)
+	assert.True(t, commit.IsBreaking())
+}
+
+func TestParse_LowercaseBreakingChangeIsNotBreaking(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("chore: cleanup\n\nbreaking change: nothing")
+
+	assert.NoError(t, err)
+	assert.False(t, commit.IsBreaking())
+	assert.Equal(t, "breaking change: nothing", commit.Body)
+}
+
+func TestParse_BodyParagraphLooksLikeFooter(t *testing.T) {
+	t.Parallel()
+
+	commit, err := Parse("docs: explain\n\nNote: this is part of the body\nand continues here.\n\nThis paragraph is not a footer.")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Note: this is part of the body\nand continues here.\n\nThis paragraph is not a footer.", commit.Body)
+	assert.Empty(t, commit.Footers)
+}
+
+func TestParseLenient_MissingBlankLine(t *testing.T) {
+	t.Parallel()
+
+	commit, err := ParseLenient("fix: prevent racing\nIntroduce a request id.\n\nRefs #123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fix", commit.Type)
+	assert.Equal(t, "prevent racing", commit.Description)
+	assert.Equal(t, "Introduce a request id.", commit.Body)
+	assert.Equal(t, []Footer{{Token: "Refs", Separator: "#", Value: "123"}}, commit.Footers)
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	for message, expected := range map[string]error{
+		"":                           ErrEmptyMessage,
+		"feat(api) missing colon":    ErrMissingSeparator,
+		"feat:missing space":         ErrMissingSeparator,
+		"feat: \n\nbody":             ErrMissingDescription,
+		"feat(): empty scope":        ErrInvalidScope,
+		"feat(api: unclosed scope":   ErrInvalidScope,
+		"add feature: not a type":    ErrInvalidType,
+		"feat: ok\nno blank line":    ErrMissingBlankLine,
+		"Merge branch 'main' into x": ErrMissingSeparator,
+	} {
+		commit, err := Parse(message)
+
+		assert.ErrorIs(t, err, expected, message)
+		assert.Nil(t, commit, message)
+	}
+}
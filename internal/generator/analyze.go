@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// analyze finds the latest release reachable from HEAD (or Options.Ref) and
+// the commits since, and computes the next release version from them.
+func analyze(repo *git.Repository, opts Options) (*Result, []versionTag, *model.VersionRange, error) {
+	log := opts.Log
+	log.Printf("Finding latest version tag")
+	tags, err := findVersionTags(repo, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	versionRange, err := findBranchRange(repo, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	heads, err := resolveHeads(repo, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tagHeads := heads
+	if opts.From != "" {
+		from, err := resolveRevision(repo, opts.From)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		tagHeads = []plumbing.Hash{from}
+	}
+	latestVersionTag, warning, err := findNearestTag(repo, tagHeads, tags, releaseInRange(versionRange), log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	result := &Result{}
+	if warning != "" {
+		result.Warnings = append(result.Warnings, warning)
+	}
+	if latestVersionTag != nil {
+		log.Printf("Latest version tag: %s\n", latestVersionTag.Name)
+		result.PreviousTag = latestVersionTag.Name
+		result.PreviousVersion = &latestVersionTag.Version
+		result.BaseCommit = latestVersionTag.Hash
+	} else {
+		log.Println("No version tag found")
+		latestVersionTag = &versionTag{}
+	}
+	if opts.From != "" {
+		result.BaseCommit = tagHeads[0].String()
+	}
+
+	headCommit, err := repo.CommitObject(heads[0])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	result.HeadCommit = headCommit.Hash.String()
+	result.Date = headCommit.Committer.When
+
+	log.Println("Finding commits since latest version tag")
+	commits, err := collectCommits(repo, heads, result.BaseCommit, opts.FirstParent)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commits, err = filterCommitsByPaths(commits, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	result.Distance = len(commits)
+	notes, err := readNotes(repo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	result.Commits = analyzeCommits(commits, notes, opts)
+
+	var cause *Commit
+	result.Bump, cause = findBump(result.Commits)
+	if cause != nil {
+		result.BumpCause = cause.Hash
+	}
+	if result.Bump < opts.MinBump {
+		log.Printf("Raising %s bump to minimum %s bump\n", result.Bump, opts.MinBump)
+		result.Bump = opts.MinBump
+		result.BumpNote = "raised to the minimum bump"
+	}
+	if err := checkAPI(repo, result, opts); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := adjustMajorZero(result, latestVersionTag.Version, opts); err != nil {
+		return nil, nil, nil, err
+	}
+	result.Version = applyBump(latestVersionTag.Version.Core(), result.Bump)
+	if err := applyReleaseAs(result, latestVersionTag.Version, opts); err != nil {
+		return nil, nil, nil, err
+	}
+	if opts.Bump != nil {
+		log.Printf("Overriding %s bump with %s bump\n", result.Bump, *opts.Bump)
+		result.Bump = *opts.Bump
+		result.BumpCause = ""
+		result.BumpNote = "overridden by the bump option"
+		result.Version = applyBump(latestVersionTag.Version.Core(), result.Bump)
+	}
+	cause = findCommit(result.Commits, result.BumpCause)
+	if err := checkRange(versionRange, result.Version, result.Bump, cause); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkGoModules(repo, result, opts); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return result, tags, versionRange, nil
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/apidiff"
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type APIReport struct {
+	// Dir is the directory of the Go module in the repository.
+	Dir    string
+	Report *apidiff.Report
+}
+
+// FindAPIChanges compares the exported API of the evaluated Go modules at the
+// previous release and HEAD.
+func FindAPIChanges(repositoryPath string, opts Options) (*Result, []APIReport, error) {
+	opts.Log.Printf("Finding API changes in %s\n", repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.APICheck = model.CheckOff
+	result, err := findNextVersion(repo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	reports, err := findAPIChanges(repo, result, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, reports, nil
+}
+
+func findAPIChanges(repo *git.Repository, result *Result, opts Options) ([]APIReport, error) {
+	if result.BaseCommit == "" {
+		return nil, nil
+	}
+	baseTree, err := commitTree(repo, result.BaseCommit)
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := commitTree(repo, result.HeadCommit)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := findEvaluatedGoModules(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []APIReport
+	for _, module := range modules {
+		if _, err := baseTree.File(path.Join(module.Dir, "go.mod")); errors.Is(err, object.ErrFileNotFound) {
+			opts.Log.Printf("Module in %s does not exist in %s, not comparing API\n", module.Dir, result.PreviousTag)
+			continue
+		}
+		opts.Log.Printf("Comparing API of module in %s with %s\n", module.Dir, result.PreviousTag)
+		report, err := apidiff.Compare(baseTree, headTree, module.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("comparing API of %s: %w", module.Dir, err)
+		}
+		reports = append(reports, APIReport{Dir: module.Dir, Report: report})
+	}
+	return reports, nil
+}
+
+// checkAPI verifies that the bump is at least the bump required by the changes
+// of the exported API since the previous release.
+func checkAPI(repo *git.Repository, result *Result, opts Options) error {
+	if opts.APICheck == "" || opts.APICheck == model.CheckOff {
+		return nil
+	}
+	reports, err := findAPIChanges(repo, result, opts)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		required := report.Report.Bump()
+		if required <= result.Bump {
+			continue
+		}
+		changes := report.Report.Changes
+		if required == model.BumpMajor {
+			changes = report.Report.Incompatible()
+		}
+		lines := make([]string, 0, len(changes))
+		for _, change := range changes {
+			lines = append(lines, "\t"+change.String())
+		}
+		message := fmt.Sprintf("the exported API of the module in %s requires a %s bump, but the commits since %s only require a %s bump:\n%s",
+			report.Dir, required, result.PreviousTag, result.Bump, strings.Join(lines, "\n"))
+		if opts.APICheck == model.CheckError {
+			return fmt.Errorf("%s", message)
+		}
+		opts.Log.Println("Warning: " + message)
+		result.Warnings = append(result.Warnings, message)
+	}
+	return nil
+}
+
+func commitTree(repo *git.Repository, hash string) (*object.Tree, error) {
+	commit, err := repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
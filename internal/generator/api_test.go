@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextVersion_APICheck(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/x\n", "feat: add module")
+	fakeCommitContent(t, repo, fs, "x.go", "package x\n\nfunc Get(key string) string { return key }\n", "feat: add get")
+	tagHead(t, repo, "1.0.0")
+	fakeCommitContent(t, repo, fs, "x.go", "package x\n\nfunc Get(key string, fallback string) string { return key }\n", "fix: support fallback")
+
+	opts := newOptions(t)
+	opts.APICheck = model.CheckError
+	_, err := findNextVersion(repo, opts)
+	assert.ErrorContains(t, err, "the exported API of the module in . requires a major bump, but the commits since 1.0.0 only require a patch bump:\n\tGet: changed type from func(key string) string to func(key string, fallback string) string")
+
+	opts.APICheck = model.CheckWarn
+	result, err := findNextVersion(repo, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Len(t, result.Warnings, 1)
+}
+
+func TestFindNextVersion_APICheck_SufficientBump(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/x\n", "feat: add module")
+	fakeCommitContent(t, repo, fs, "x.go", "package x\n\nfunc Get() {}\n", "feat: add get")
+	tagHead(t, repo, "1.0.0")
+	fakeCommitContent(t, repo, fs, "y.go", "package x\n\nfunc Set() {}\n", "feat: add set")
+	opts := newOptions(t)
+	opts.APICheck = model.CheckError
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestFindAPIChanges(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/x\n", "feat: add module")
+	fakeCommitContent(t, repo, fs, "x.go", "package x\n\nfunc Get() {}\n", "feat: add get")
+	tagHead(t, repo, "1.0.0")
+	fakeCommitContent(t, repo, fs, "y.go", "package x\n\nfunc Set() {}\n", "chore: add set")
+	result, err := findNextVersion(repo, newOptions(t))
+	assert.NoError(t, err)
+	reports, err := findAPIChanges(repo, result, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, ".", reports[0].Dir)
+	assert.Equal(t, model.BumpMinor, reports[0].Report.Bump())
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// currentBranch returns the branch the release is made on: Options.Branch,
+// the target of a predicted merge, the branch of Options.Ref or the current
+// branch. It is empty if the evaluated revision is not a branch.
+func currentBranch(repo *git.Repository, opts Options) (string, error) {
+	if opts.Branch != "" {
+		return opts.Branch, nil
+	}
+	if opts.Target != "" {
+		return opts.Target, nil
+	}
+	if opts.Ref != "" {
+		if _, err := repo.Reference(plumbing.NewBranchReferenceName(opts.Ref), false); err != nil {
+			return "", nil
+		}
+		return opts.Ref, nil
+	}
+	headRef, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	if !headRef.Name().IsBranch() {
+		return "", nil
+	}
+	return headRef.Name().Short(), nil
+}
+
+func findBranchRange(repo *git.Repository, opts Options) (*model.VersionRange, error) {
+	if len(opts.BranchRules) == 0 {
+		return nil, nil
+	}
+	branch, err := currentBranch(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		opts.Log.Println("The evaluated revision is not a branch, no branch rule applies")
+		return nil, nil
+	}
+
+	for _, rule := range opts.BranchRules {
+		versionRange, err := rule.Resolve(branch)
+		if err != nil {
+			return nil, err
+		}
+		if versionRange != nil {
+			opts.Log.Printf("Branch %s matches rule %s, restricting versions to %s\n", branch, rule.Pattern, versionRange)
+			return versionRange, nil
+		}
+	}
+	return nil, nil
+}
+
+func releaseInRange(versionRange *model.VersionRange) func(versionTag) bool {
+	if versionRange == nil {
+		return isRelease
+	}
+	return func(tag versionTag) bool {
+		return isRelease(tag) && versionRange.Contains(tag.Version)
+	}
+}
+
+func checkRange(versionRange *model.VersionRange, version semver.SemVer, bump model.Bump, cause *Commit) error {
+	if versionRange == nil || versionRange.Contains(version) {
+		return nil
+	}
+	if cause != nil {
+		return fmt.Errorf("commit %s requires a %s bump to %s, which is outside of the allowed version range %s", cause.Hash, bump, version, versionRange)
+	}
+	return fmt.Errorf("version %s is outside of the allowed version range %s", version, versionRange)
+}
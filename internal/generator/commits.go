@@ -0,0 +1,260 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// collectCommits returns the commits reachable from any of the heads but not
+// from the base commit, if any.
+func collectCommits(repo *git.Repository, heads []plumbing.Hash, base string, firstParent bool) ([]*object.Commit, error) {
+	var bases []plumbing.Hash
+	if base != "" {
+		bases = append(bases, plumbing.NewHash(base))
+	}
+	var commits []*object.Commit
+	for _, head := range heads {
+		headCommits, err := collectCommitsFrom(repo, head, bases, firstParent)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, headCommits...)
+		bases = append(bases, head)
+	}
+	return commits, nil
+}
+
+// collectCommitsFrom returns the commits reachable from the commit but not
+// from any of the bases like `git log ^base from`, newest first in depth-first
+// order. With firstParent only the first parent of merge commits is followed,
+// so the commits of merged branches are represented by their merge commit.
+func collectCommitsFrom(repo *git.Repository, from plumbing.Hash, bases []plumbing.Hash, firstParent bool) ([]*object.Commit, error) {
+	boundary, err := shallowBoundary(repo)
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[plumbing.Hash]bool{}
+	for _, base := range bases {
+		if err := markAncestors(repo, base, boundary, excluded); err != nil {
+			return nil, err
+		}
+	}
+
+	var commits []*object.Commit
+	visited := map[plumbing.Hash]bool{}
+	stack := []plumbing.Hash{from}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[hash] || excluded[hash] {
+			continue
+		}
+		visited[hash] = true
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c)
+
+		parents := parentHashes(repo, c, boundary)
+		if firstParent && len(parents) > 1 {
+			parents = parents[:1]
+		}
+		for i := len(parents) - 1; i >= 0; i-- {
+			stack = append(stack, parents[i])
+		}
+	}
+	return commits, nil
+}
+
+// markAncestors adds the commit and all of its ancestors to the set, up to the
+// shallow boundary.
+func markAncestors(repo *git.Repository, from plumbing.Hash, boundary, ancestors map[plumbing.Hash]bool) error {
+	stack := []plumbing.Hash{from}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if ancestors[hash] {
+			continue
+		}
+		ancestors[hash] = true
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		stack = append(stack, parentHashes(repo, c, boundary)...)
+	}
+	return nil
+}
+
+func analyzeCommits(commits []*object.Commit, notes map[string]string, opts Options) []Commit {
+	analyzed := make([]Commit, 0, len(commits))
+	for _, c := range commits {
+		if note, ok := notes[c.Hash.String()]; ok {
+			opts.Log.Printf("Commit %s is overridden by a note\n", c.Hash)
+			analyzed = append(analyzed, analyzeCommit(c, commitMessage{raw: note, source: " (git note)"}, opts))
+			continue
+		}
+		for _, message := range commitMessages(c, opts) {
+			analyzed = append(analyzed, analyzeCommit(c, message, opts))
+		}
+	}
+	cancelReverts(analyzed, opts.Log)
+	return analyzed
+}
+
+// analyzeCommit classifies a message of the commit, which is its own message
+// unless replaced by a note in NotesRef or split by the merge strategy.
+func analyzeCommit(c *object.Commit, message commitMessage, opts Options) Commit {
+	commit := Commit{
+		Hash:    c.Hash.String(),
+		Subject: strings.TrimSpace(strings.SplitN(message.raw, "\n", 2)[0]),
+		Raw:     message.raw,
+		Date:    c.Committer.When,
+	}
+	opts.Log.Printf("Commit: [%s] %s\n", commit.Hash, commit.Subject)
+	if message.skip {
+		opts.Log.Printf("Skipping merge commit %s\n", commit.Hash)
+		commit.Rule = "skipped" + message.source
+		return commit
+	}
+	commit.Bump, commit.Rule = classifyCommit(&commit, opts.IncMapping, opts.Log)
+	commit.Rule += message.source
+	commit.Reverts = findReverted(&commit)
+	return commit
+}
+
+func classifyCommit(commit *Commit, incMapping []model.Tuple[string, model.Bump], log logger.Logger) (model.Bump, string) {
+	msg, err := conventional.Parse(commit.Raw)
+	if errors.Is(err, conventional.ErrMissingBlankLine) {
+		log.Printf("Warning: commit %s has no blank line after the header, reading the following lines as body\n", commit.Hash)
+		msg, err = conventional.ParseLenient(commit.Raw)
+	}
+	if err != nil {
+		log.Printf("Commit %s is not a conventional commit (%s), ignoring\n", commit.Hash, err)
+		return model.BumpNone, "not conventional: " + err.Error()
+	}
+	commit.Message = msg
+
+	if value, ok := msg.Footer("Release-As"); ok {
+		version, err := semver.Parse(value)
+		if err == nil && len(version.PreRelease) == 0 {
+			log.Printf("Commit %s requests release %s\n", commit.Hash, version)
+			commit.ReleaseAs = &version
+		} else {
+			log.Printf("Commit %s has an invalid Release-As footer '%s', ignoring it\n", commit.Hash, value)
+		}
+	}
+
+	if msg.IsBreaking() {
+		log.Printf("Found major version bump commit %s (breaking change)\n", commit.Hash)
+		if msg.BreakingMarker {
+			return model.BumpMajor, "breaking change (!)"
+		}
+		return model.BumpMajor, "breaking change (footer)"
+	}
+	for _, mapping := range incMapping {
+		if strings.EqualFold(msg.Type, mapping.First) {
+			log.Printf("Found %s version bump commit %s\n", mapping.Second, commit.Hash)
+			return mapping.Second, mapping.First + ": " + mapping.Second.String()
+		}
+	}
+	return model.BumpNone, "no mapping for type " + msg.Type
+}
+
+func findBump(commits []Commit) (model.Bump, *Commit) {
+	bump := model.BumpNone
+	var cause *Commit
+	for i, c := range commits {
+		if c.Bump > bump {
+			bump = c.Bump
+			cause = &commits[i]
+		}
+	}
+	return bump, cause
+}
+
+// adjustMajorZero applies the initial development semantics and the promotion
+// to stable to the bump of a version with major version 0.
+func adjustMajorZero(result *Result, previous semver.SemVer, opts Options) error {
+	if opts.PromoteToStable {
+		if previous.Major != 0 {
+			return fmt.Errorf("cannot promote %s to stable, the major version is already %d", previous, previous.Major)
+		}
+		opts.Log.Println("Promoting to stable version 1.0.0")
+		result.Bump = model.BumpMajor
+		result.BumpCause = ""
+		result.BumpNote = "promoted to stable"
+		return nil
+	}
+	if !opts.InitialDevelopment || previous.Major != 0 || result.Bump < model.BumpMinor {
+		return nil
+	}
+	lowered := result.Bump - 1
+	opts.Log.Printf("Lowering %s bump to %s bump during initial development\n", result.Bump, lowered)
+	result.BumpNote = fmt.Sprintf("lowered from %s during initial development", result.Bump)
+	result.Bump = lowered
+	return nil
+}
+
+// applyReleaseAs sets the version to the highest version requested by a
+// Release-As footer, unless the bump is overridden.
+func applyReleaseAs(result *Result, previous semver.SemVer, opts Options) error {
+	var cause *Commit
+	for i, c := range result.Commits {
+		if c.ReleaseAs != nil && !c.Cancelled && (cause == nil || cause.ReleaseAs.LessThan(*c.ReleaseAs)) {
+			cause = &result.Commits[i]
+		}
+	}
+	if cause == nil || opts.Bump != nil {
+		return nil
+	}
+	version := *cause.ReleaseAs
+	if !previous.LessThan(version) {
+		return fmt.Errorf("commit %s requests release %s, which is not higher than the previous version %s", cause.Hash, version, previous)
+	}
+	opts.Log.Printf("Releasing as %s requested by commit %s\n", version, cause.Hash)
+	result.Version = version
+	result.BumpCause = cause.Hash
+	result.BumpNote = "Release-As " + version.String()
+	switch {
+	case version.Major != previous.Major:
+		result.Bump = model.BumpMajor
+	case version.Minor != previous.Minor:
+		result.Bump = model.BumpMinor
+	default:
+		result.Bump = model.BumpPatch
+	}
+	return nil
+}
+
+func findCommit(commits []Commit, hash string) *Commit {
+	for i := range commits {
+		if commits[i].Hash == hash {
+			return &commits[i]
+		}
+	}
+	return nil
+}
+
+func applyBump(version semver.SemVer, bump model.Bump) semver.SemVer {
+	switch bump {
+	case model.BumpMajor:
+		return version.IncMajor()
+	case model.BumpMinor:
+		return version.IncMinor()
+	case model.BumpPatch:
+		return version.IncPatch()
+	}
+	return version
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextVersion_SideBranchCommitsOlderThanTagAreExcluded(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	checkoutBranch(t, repo, "feature", true)
+	fakeCommit(t, repo, fs, "feature.go", "feat!: old feature")
+	checkoutBranch(t, repo, "main", false)
+	fakeMergeCommit(t, repo, fs, "merge.go", "feature", "Merge branch 'feature'")
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Len(t, result.Commits, 1)
+}
+
+func TestFindNextVersion_MergedBranchCommitsAreIncluded(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "feature", true)
+	fakeCommit(t, repo, fs, "feature.go", "feat: new feature")
+	fakeCommit(t, repo, fs, "feature_test.go", "test: cover new feature")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	fakeMergeCommit(t, repo, fs, "merge.go", "feature", "Merge branch 'feature'")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	subjects := []string{}
+	for _, commit := range result.Commits {
+		subjects = append(subjects, commit.Subject)
+	}
+	assert.Equal(t, []string{"Merge branch 'feature'", "fix: fix a bug", "test: cover new feature", "feat: new feature"}, subjects)
+}
+
+func TestFindNextVersion_FirstParent(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "feat/new-feature", true)
+	fakeCommit(t, repo, fs, "feature.go", "feat!: wip")
+	fakeCommit(t, repo, fs, "feature_test.go", "test: cover new feature")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	fakeMergeCommit(t, repo, fs, "merge.go", "feat/new-feature", "Merge pull request #1 from org/feat/new-feature")
+	opts := newOptions(t)
+	opts.FirstParent = true
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Len(t, result.Commits, 2)
+
+	opts.MergeStrategy = model.MergeBranch
+	result, err = findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+}
+
+func TestFindNextVersion_ShallowClone(t *testing.T) {
+	t.Parallel()
+
+	repo := newShallowClone(t, 2, []string{"init", "feat: a", "feat: b", "feat: c", "feat: d", "fix: e"}, map[int]string{3: "1.0.0"})
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Len(t, result.Commits, 2)
+
+	commits, err := findCommits(repo, "", "", newOptions(t))
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+)
+
+type ComponentResult struct {
+	Component model.Component
+	Result    *Result
+	// Reasons explains why the component is bumped, i.e. the commit that
+	// determines the bump and the released dependencies.
+	Reasons []string
+}
+
+// ComponentOptions returns the options to evaluate a single component: only
+// commits touching its paths and only tags in its tag format are considered.
+func ComponentOptions(component model.Component, opts Options) Options {
+	opts.Paths = component.Paths
+	opts.ExcludePaths = component.Exclude
+	opts.TagFormat = component.TagFormat
+	opts.IgnoreForeignTags = true
+	return opts
+}
+
+// FindComponentVersions computes the next version of every component. With a
+// non-empty channel, pre-releases of that channel are computed instead.
+// Components whose dependencies are released get at least a patch bump.
+func FindComponentVersions(repositoryPath string, components []model.Component, channel string, opts Options) ([]ComponentResult, error) {
+	opts.Log.Printf("Finding next versions of %d components in %s\n", len(components), repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findComponentVersions(repo, components, channel, opts)
+}
+
+func findComponentVersions(repo *git.Repository, components []model.Component, channel string, opts Options) ([]ComponentResult, error) {
+	var err error
+	if opts.DiscoverDependencies {
+		if components, err = discoverDependencies(repo, components, opts); err != nil {
+			return nil, err
+		}
+	}
+	sorted, err := sortComponents(components)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]ComponentResult{}
+	for _, component := range sorted {
+		opts.Log.Printf("Evaluating component %s\n", component.Name)
+		componentOpts := ComponentOptions(component, opts)
+
+		var reasons []string
+		for _, name := range component.DependsOn {
+			if dependency := byName[name]; dependency.Result.Bump != model.BumpNone {
+				reasons = append(reasons, fmt.Sprintf("dependency %s is released as %s", name, dependency.Result.Tag))
+				componentOpts.MinBump = model.BumpPatch
+			}
+		}
+
+		var result *Result
+		if channel == "" {
+			result, err = findNextVersion(repo, componentOpts)
+		} else {
+			result, err = findNextPreRelease(repo, channel, componentOpts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", component.Name, err)
+		}
+		if bump, cause := findBump(result.Commits); cause != nil {
+			reasons = append([]string{fmt.Sprintf("commit %s requires a %s bump", cause.Hash, bump)}, reasons...)
+		}
+		byName[component.Name] = ComponentResult{Component: component, Result: result, Reasons: reasons}
+	}
+
+	results := make([]ComponentResult, 0, len(components))
+	for _, component := range components {
+		results = append(results, byName[component.Name])
+	}
+	return results, nil
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindComponentVersions(t *testing.T) {
+	t.Parallel()
+
+	components := []model.Component{
+		{Name: "svc-a", Paths: []string{"services/a"}, TagFormat: model.DefaultComponentTagFormat("svc-a")},
+		{Name: "svc-b", Paths: []string{"services/b"}, TagFormat: model.DefaultComponentTagFormat("svc-b")},
+		{Name: "libs", Paths: []string{"libs/**"}, TagFormat: model.TagFormat{Prefix: "libs-"}},
+	}
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "services/a/main.go", "feat: initial a")
+	fakeCommit(t, repo, fs, "services/b/main.go", "feat: initial b")
+	tagHead(t, repo, "svc-a/v1.0.0")
+	tagHead(t, repo, "svc-b/v2.3.0")
+	tagHead(t, repo, "unrelated")
+	fakeCommit(t, repo, fs, "services/a/util.go", "fix: fix a")
+	fakeCommit(t, repo, fs, "services/b/api.go", "feat!: break b")
+	results, err := findComponentVersions(repo, components, "", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "svc-a", results[0].Component.Name)
+	assert.Equal(t, "svc-a/v1.0.1", results[0].Result.Tag)
+	assert.Equal(t, "svc-a/v1.0.0", results[0].Result.PreviousTag)
+	assert.Len(t, results[0].Result.Commits, 1)
+	assert.Equal(t, "svc-b/v3.0.0", results[1].Result.Tag)
+	assert.Equal(t, "libs-0.0.0", results[2].Result.Tag)
+	assert.Equal(t, model.BumpNone, results[2].Result.Bump)
+}
+
+func TestFindComponentVersions_PreRelease(t *testing.T) {
+	t.Parallel()
+
+	components := []model.Component{
+		{Name: "svc-a", Paths: []string{"services/a"}, TagFormat: model.DefaultComponentTagFormat("svc-a")},
+	}
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "services/a/main.go", "feat: initial a")
+	tagHead(t, repo, "svc-a/v1.0.0")
+	fakeCommit(t, repo, fs, "services/a/util.go", "feat: next a")
+	tagHead(t, repo, "svc-a/v1.1.0-beta.1")
+	fakeCommit(t, repo, fs, "services/a/more.go", "fix: fix a")
+	results, err := findComponentVersions(repo, components, "beta", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "svc-a/v1.1.0-beta.2", results[0].Result.Tag)
+}
+
+func TestFindComponentVersions_PropagatesToDependents(t *testing.T) {
+	t.Parallel()
+
+	components := []model.Component{
+		{Name: "svc", Paths: []string{"svc"}, TagFormat: model.DefaultComponentTagFormat("svc"), DependsOn: []string{"core"}},
+		{Name: "core", Paths: []string{"core"}, TagFormat: model.DefaultComponentTagFormat("core"), DependsOn: []string{"libs"}},
+		{Name: "libs", Paths: []string{"libs"}, TagFormat: model.DefaultComponentTagFormat("libs")},
+		{Name: "other", Paths: []string{"other"}, TagFormat: model.DefaultComponentTagFormat("other")},
+	}
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "svc/main.go", "feat: initial svc")
+	fakeCommit(t, repo, fs, "core/main.go", "feat: initial core")
+	fakeCommit(t, repo, fs, "libs/main.go", "feat: initial libs")
+	fakeCommit(t, repo, fs, "other/main.go", "feat: initial other")
+	for _, tag := range []string{"svc/v1.0.0", "core/v1.0.0", "libs/v1.0.0", "other/v1.0.0"} {
+		tagHead(t, repo, tag)
+	}
+	fakeCommit(t, repo, fs, "libs/util.go", "feat: new helper")
+	fakeCommit(t, repo, fs, "svc/util.go", "feat: new endpoint")
+	results, err := findComponentVersions(repo, components, "", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "svc", results[0].Component.Name)
+	assert.Equal(t, "svc/v1.1.0", results[0].Result.Tag)
+	assert.Len(t, results[0].Reasons, 2)
+	assert.Contains(t, results[0].Reasons[1], "dependency core is released as core/v1.0.1")
+	assert.Equal(t, "core/v1.0.1", results[1].Result.Tag)
+	assert.Equal(t, []string{"dependency libs is released as libs/v1.1.0"}, results[1].Reasons)
+	assert.Equal(t, "libs/v1.1.0", results[2].Result.Tag)
+	assert.Len(t, results[2].Reasons, 1)
+	assert.Contains(t, results[2].Reasons[0], "requires a minor bump")
+	assert.Equal(t, "other/v1.0.0", results[3].Result.Tag)
+	assert.Empty(t, results[3].Reasons)
+}
+
+func TestFindComponentVersions_DiscoversGoModuleDependencies(t *testing.T) {
+	t.Parallel()
+
+	components := []model.Component{
+		{Name: "svc", Paths: []string{"svc"}, TagFormat: model.DefaultComponentTagFormat("svc")},
+		{Name: "api", Paths: []string{"api"}, TagFormat: model.DefaultComponentTagFormat("api")},
+		{Name: "libs", Paths: []string{"libs"}, TagFormat: model.DefaultComponentTagFormat("libs")},
+	}
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.work", "go 1.23\n\nuse (\n\t./api\n\t./libs\n)\n", "chore: add workspace")
+	fakeCommitContent(t, repo, fs, "libs/go.mod", "module example.com/libs\n", "chore: add libs")
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/api\n\nrequire example.com/libs v1.0.0\n", "chore: add api")
+	fakeCommitContent(t, repo, fs, "svc/go.mod", "module example.com/svc\n\nrequire example.com/api v1.0.0\n\nreplace example.com/api => ../api\n", "chore: add svc")
+	for _, tag := range []string{"svc/v1.0.0", "api/v1.0.0", "libs/v1.0.0"} {
+		tagHead(t, repo, tag)
+	}
+	fakeCommit(t, repo, fs, "libs/util.go", "fix: fix helper")
+	opts := newOptions(t)
+	opts.DiscoverDependencies = true
+	results, err := findComponentVersions(repo, components, "", opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "svc/v1.0.1", results[0].Result.Tag)
+	assert.Equal(t, []string{"api"}, results[0].Component.DependsOn)
+	assert.Equal(t, "api/v1.0.1", results[1].Result.Tag)
+	assert.Equal(t, []string{"libs"}, results[1].Component.DependsOn)
+	assert.Equal(t, "libs/v1.0.1", results[2].Result.Tag)
+}
+
+func TestFindComponentVersions_DependencyCycle(t *testing.T) {
+	t.Parallel()
+
+	components := []model.Component{
+		{Name: "a", Paths: []string{"a"}, TagFormat: model.DefaultComponentTagFormat("a"), DependsOn: []string{"b"}},
+		{Name: "b", Paths: []string{"b"}, TagFormat: model.DefaultComponentTagFormat("b"), DependsOn: []string{"a"}},
+	}
+	repo, _ := NewSimulatedRepository(t)
+	_, err := findComponentVersions(repo, components, "", newOptions(t))
+
+	assert.ErrorContains(t, err, "dependency cycle between components a -> b -> a")
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/gomod"
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type goModule struct {
+	Dir   string
+	File  *gomod.File
+	Owner string
+}
+
+// findGoModules returns the modules of all go.mod files in the HEAD (or
+// Options.Ref) tree, and the directories used by the go.work file in the
+// root, if any.
+func findGoModules(repo *git.Repository, opts Options) ([]goModule, map[string]bool, error) {
+	head, err := resolveHead(repo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	headCommit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := headCommit.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var modules []goModule
+	var workspace map[string]bool
+	err = tree.Files().ForEach(func(f *object.File) error {
+		switch {
+		case f.Name == "go.work":
+			content, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			work, err := gomod.ParseWork([]byte(content))
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.Name, err)
+			}
+			workspace = map[string]bool{}
+			for _, use := range work.Uses {
+				workspace[path.Clean(use)] = true
+			}
+		case path.Base(f.Name) == "go.mod":
+			content, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			file, err := gomod.Parse([]byte(content))
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.Name, err)
+			}
+			modules = append(modules, goModule{Dir: path.Dir(f.Name), File: file})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return modules, workspace, nil
+}
+
+// discoverDependencies adds the dependencies between components derived from
+// their Go modules: a module depends on another module if it replaces it with
+// its directory, or if it requires it and both are used by the go.work file.
+func discoverDependencies(repo *git.Repository, components []model.Component, opts Options) ([]model.Component, error) {
+	modules, workspace, err := findGoModules(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := map[string]*goModule{}
+	byDir := map[string]*goModule{}
+	for i := range modules {
+		module := &modules[i]
+		for _, component := range components {
+			if touchesAny(component.Paths, component.Exclude, []string{path.Join(module.Dir, "go.mod")}) {
+				module.Owner = component.Name
+				break
+			}
+		}
+		byPath[module.File.Module] = module
+		byDir[module.Dir] = module
+	}
+
+	discovered := make([]model.Component, 0, len(components))
+	for _, component := range components {
+		dependsOn := append([]string{}, component.DependsOn...)
+		add := func(dependency *goModule, reason string) {
+			if dependency == nil || dependency.Owner == "" || dependency.Owner == component.Name || contains(dependsOn, dependency.Owner) {
+				return
+			}
+			opts.Log.Printf("Component %s depends on %s (%s)\n", component.Name, dependency.Owner, reason)
+			dependsOn = append(dependsOn, dependency.Owner)
+		}
+		for _, module := range modules {
+			if module.Owner != component.Name {
+				continue
+			}
+			for _, replace := range module.File.Replaces {
+				if replace.IsLocal() {
+					add(byDir[path.Join(module.Dir, replace.New)], "replace "+replace.Old+" => "+replace.New)
+				}
+			}
+			for _, require := range module.File.Requires {
+				if dependency := byPath[require.Path]; dependency != nil && workspace[module.Dir] && workspace[dependency.Dir] {
+					add(dependency, "go.work requires "+require.Path)
+				}
+			}
+		}
+		component.DependsOn = dependsOn
+		discovered = append(discovered, component)
+	}
+	return discovered, nil
+}
+
+// sortComponents orders the components so that every component comes after
+// its dependencies.
+func sortComponents(components []model.Component) ([]model.Component, error) {
+	byName := map[string]model.Component{}
+	for _, component := range components {
+		byName[component.Name] = component
+	}
+
+	sorted := make([]model.Component, 0, len(components))
+	state := map[string]int{}
+	var visit func(component model.Component, stack []string) error
+	visit = func(component model.Component, stack []string) error {
+		stack = append(stack, component.Name)
+		switch state[component.Name] {
+		case 1:
+			return fmt.Errorf("dependency cycle between components %s", strings.Join(stack, " -> "))
+		case 2:
+			return nil
+		}
+		state[component.Name] = 1
+		for _, name := range component.DependsOn {
+			dependency, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("component %s depends on unknown component %s", component.Name, name)
+			}
+			if err := visit(dependency, stack); err != nil {
+				return err
+			}
+		}
+		state[component.Name] = 2
+		sorted = append(sorted, component)
+		return nil
+	}
+	for _, component := range components {
+		if err := visit(component, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func FindNextPreRelease(repositoryPath string, channel string, opts Options) (*Result, error) {
+	opts.Log.Printf("Finding next %s pre-release in %s\n", channel, repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findNextPreRelease(repo, channel, opts)
+}
+
+func findNextPreRelease(repo *git.Repository, channel string, opts Options) (*Result, error) {
+	log := opts.Log
+	if err := model.ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+
+	result, tags, versionRange, err := analyze(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	target := result.Version
+
+	preReleaseTagged := tagsByHash(tags, isPreRelease)
+	var preReleases []versionTag
+	err = walkUntilTagged(repo, plumbing.NewHash(result.HeadCommit), tagsByHash(tags, isRelease), func(c *object.Commit, _ []versionTag) {
+		preReleases = append(preReleases, preReleaseTagged[c.Hash]...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A pre-release of a higher version than the bump suggests, e.g. a beta
+	// for the next minor while only fixes were committed since, is continued.
+	for _, tag := range preReleases {
+		if target.LessThan(tag.Version.Core()) && (versionRange == nil || versionRange.Contains(tag.Version)) {
+			target = tag.Version.Core()
+		}
+	}
+	// Without a bump, the target would be the previous release itself, whose
+	// pre-releases sort below it.
+	if result.PreviousVersion != nil && !result.PreviousVersion.LessThan(target) {
+		log.Printf("No bump since %s, targeting the next patch version\n", result.PreviousTag)
+		target = result.PreviousVersion.IncPatch()
+	}
+	log.Printf("Target version: %s\n", target)
+
+	var latestPreRelease *versionTag
+	var counter uint64
+	for i, tag := range preReleases {
+		if !tag.Version.Core().Equal(target) {
+			continue
+		}
+		if latestPreRelease == nil || latestPreRelease.Version.LessThan(tag.Version) {
+			latestPreRelease = &preReleases[i]
+		}
+		if n, ok := channelCounter(tag.Version, channel); ok && n >= counter {
+			counter = n + 1
+		}
+	}
+	if counter == 0 {
+		counter = 1
+	}
+
+	version := target
+	version.PreRelease = []string{channel, strconv.FormatUint(counter, 10)}
+	if latestPreRelease != nil {
+		log.Printf("Latest pre-release tag of target version: %s\n", latestPreRelease.Name)
+		if !latestPreRelease.Version.LessThan(version) {
+			return nil, fmt.Errorf("pre-release %s would not be greater than existing pre-release %s", opts.TagFormat.Format(version.String()), latestPreRelease.Name)
+		}
+	}
+
+	result.Version = version
+	result.Tag = opts.TagFormat.Format(version.String())
+
+	return result, nil
+}
+
+// channelCounter returns N for pre-releases of the form <channel>.N, or 0 for
+// a bare <channel> pre-release.
+func channelCounter(version semver.SemVer, channel string) (uint64, bool) {
+	if version.PreRelease[0] != channel {
+		return 0, false
+	}
+	switch len(version.PreRelease) {
+	case 1:
+		return 0, true
+	case 2:
+		n, err := strconv.ParseUint(version.PreRelease[1], 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
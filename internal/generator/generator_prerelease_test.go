@@ -0,0 +1,407 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextPreRelease_EmptyRepository(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.0.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_NoTag_PatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.0.1-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_NoTag_FeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.1.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_NoTag_BreakingChangeCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_rc1_IncrementRC(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.1", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0-rc.2", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_rc2_IncrementRC(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.2", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0-rc.3", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_PatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_NonBumpingCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "chore: update dependencies")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_FeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.1.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_BreakingChangeCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "2.0.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_InvalidTag_NoCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("invalid", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindNextPreRelease_InvalidTagButIgnored_BreakingChangeCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("invalid", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
+	opts := newOptions(t)
+	opts.IgnoreInvalidTags = true
+	result, err := findNextPreRelease(repo, "rc", opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_VPrefixedTagFormat_IncrementRC(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0-rc.1", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	opts := newOptions(t)
+	opts.TagFormat, err = model.ParseTagFormat("v{version}")
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "v1.0.0-rc.2", result.Tag)
+}
+
+func TestFindNextPreRelease_ReleaseAfterRC_PatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.3", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Tag1_0_0_rc9_IncrementRCNumerically(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.9", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	headRef, err = repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0-rc.10", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0-rc.11", result.Tag)
+}
+
+func TestFindNextPreRelease_Alpha_NoTag_FeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	result, err := findNextPreRelease(repo, "alpha", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.1.0-alpha.1", result.Tag)
+}
+
+func TestFindNextPreRelease_Beta_IncrementBeta(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	headRef, err = repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-alpha.2", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-beta.4", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	result, err := findNextPreRelease(repo, "beta", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.3.0-beta.5", result.Tag)
+}
+
+func TestFindNextPreRelease_PromoteBetaToRC(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	headRef, err = repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-beta.4", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.3.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_ContinueHigherPreReleaseOnPatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	headRef, err = repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-beta.1", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "preview", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.3.0-preview.1", result.Tag)
+}
+
+func TestFindNextPreRelease_BreakingChangeAfterPreRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	headRef, err = repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-rc.2", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "util.go", "feat!: drop something")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "2.0.0-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_DemoteRCToBetaFails(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.3.0-rc.1", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextPreRelease(repo, "beta", newOptions(t))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindNextPreRelease_InvalidChannel(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	for _, channel := range []string{"", "1", "rc.1", "r_c"} {
+		result, err := findNextPreRelease(repo, channel, newOptions(t))
+
+		assert.Error(t, err, channel)
+		assert.Nil(t, result, channel)
+	}
+}
+
+func TestFindNextPreRelease_IgnoresPreReleaseOnUnrelatedBranch(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "next", true)
+	fakeCommit(t, repo, fs, "next.go", "feat!: next major")
+	tagHead(t, repo, "2.0.0-rc.1")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextPreRelease(repo, "rc", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1-rc.1", result.Tag)
+}
+
+func TestFindNextPreRelease_MaintenanceBranch_IgnoresPreReleaseOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.4.0")
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	tagHead(t, repo, "1.5.0-beta.1")
+	checkoutBranch(t, repo, "release/1.4", true)
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "release/*"}}
+	result, err := findNextPreRelease(repo, "rc", opts)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindNextPreRelease_PromoteToStable(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "0.9.2")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	tagHead(t, repo, "1.0.0-rc.1")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix another bug")
+	opts := newOptions(t)
+	opts.PromoteToStable = true
+	result, err := findNextPreRelease(repo, "rc", opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0-rc.2", result.Tag)
+}
@@ -3,7 +3,7 @@ package generator
 import (
 	"testing"
 
-	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/internal/model"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,11 +12,11 @@ func TestFindNextVersion_EmptyRepository(t *testing.T) {
 	t.Parallel()
 
 	repo, _ := NewSimulatedRepository(t)
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "0.0.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.0.0", result.Tag)
 }
 
 func TestFindNextVersion_NoTag_PatchCommit(t *testing.T) {
@@ -24,11 +24,11 @@ func TestFindNextVersion_NoTag_PatchCommit(t *testing.T) {
 
 	repo, fs := NewSimulatedRepository(t)
 	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "0.0.1", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.0.1", result.Tag)
 }
 
 func TestFindNextVersion_NoTag_FeatCommit(t *testing.T) {
@@ -36,11 +36,11 @@ func TestFindNextVersion_NoTag_FeatCommit(t *testing.T) {
 
 	repo, fs := NewSimulatedRepository(t)
 	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "0.1.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.1.0", result.Tag)
 }
 
 func TestFindNextVersion_NoTag_BreakingChangeCommit(t *testing.T) {
@@ -48,11 +48,11 @@ func TestFindNextVersion_NoTag_BreakingChangeCommit(t *testing.T) {
 
 	repo, fs := NewSimulatedRepository(t)
 	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "1.0.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0", result.Tag)
 }
 
 func TestFindNextVersion_Tag1_0_0_PatchCommit(t *testing.T) {
@@ -64,11 +64,11 @@ func TestFindNextVersion_Tag1_0_0_PatchCommit(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
 	assert.NoError(t, err)
 	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "1.0.1", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1", result.Tag)
 }
 
 func TestFindNextVersion_Tag1_0_0_FeatCommit(t *testing.T) {
@@ -80,11 +80,11 @@ func TestFindNextVersion_Tag1_0_0_FeatCommit(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
 	assert.NoError(t, err)
 	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "1.1.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.1.0", result.Tag)
 }
 
 func TestFindNextVersion_Tag1_0_0_BreakingChangeCommit(t *testing.T) {
@@ -96,11 +96,11 @@ func TestFindNextVersion_Tag1_0_0_BreakingChangeCommit(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
 	assert.NoError(t, err)
 	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "2.0.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "2.0.0", result.Tag)
 }
 
 func TestFindNextVersion_Tag1_0_0_rc_BreakingChangeCommit(t *testing.T) {
@@ -112,11 +112,11 @@ func TestFindNextVersion_Tag1_0_0_rc_BreakingChangeCommit(t *testing.T) {
 	_, err = repo.CreateTag("1.0.0-rc.1", headRef.Hash(), nil)
 	assert.NoError(t, err)
 	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "1.0.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0", result.Tag)
 }
 
 func TestFindNextVersion_InvalidTag_NoCommit(t *testing.T) {
@@ -127,10 +127,10 @@ func TestFindNextVersion_InvalidTag_NoCommit(t *testing.T) {
 	assert.NoError(t, err)
 	_, err = repo.CreateTag("invalid", headRef.Hash(), nil)
 	assert.NoError(t, err)
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, false)
+	result, err := findNextVersion(repo, newOptions(t))
 
 	assert.Error(t, err)
-	assert.Nil(t, tag)
+	assert.Nil(t, result)
 }
 
 func TestFindNextVersion_InvalidTagButIgnored_BreakingChangeCommit(t *testing.T) {
@@ -142,9 +142,440 @@ func TestFindNextVersion_InvalidTagButIgnored_BreakingChangeCommit(t *testing.T)
 	_, err = repo.CreateTag("1.0.0-rc.1", headRef.Hash(), nil)
 	assert.NoError(t, err)
 	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
-	tag, err := findNextVersion(repo, conventionalCommitToSemVer, logger.Silent{}, true)
+	opts := newOptions(t)
+	opts.IgnoreInvalidTags = true
+	result, err := findNextVersion(repo, opts)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, tag)
-	assert.Equal(t, "1.0.0", *tag)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0", result.Tag)
+}
+
+func TestFindNextVersion_Tag1_0_0_TypeWithSharedPrefixIgnored(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feature: not a feat")
+	fakeCommit(t, repo, fs, "util.go", "fixup: not a fix")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.0", result.Tag)
+}
+
+func TestFindNextVersion_Tag1_0_0_BreakingChangeFooter(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix(api): change response\n\nBREAKING CHANGE: response is now an object")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "2.0.0", result.Tag)
+}
+
+func TestFindNextVersion_Tag1_0_0_ScopedFeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	fakeCommit(t, repo, fs, "cli.go", "Feat(cli): some new feature")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.1.0", result.Tag)
+}
+
+func TestFindNextVersion_Tag1_0_0_MissingBlankLineAfterHeader(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug\nThe body directly follows the header.")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1", result.Tag)
+}
+
+func TestFindNextVersion_Tag1_0_0_ExplainsCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "update readme")
+	fakeCommit(t, repo, fs, "docs.go", "docs: add usage")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	fakeCommit(t, repo, fs, "cli.go", "feat(cli)!: new flags")
+	fakeCommit(t, repo, fs, "api.go", "feat: new endpoint\n\nBREAKING CHANGE: removed the old one")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	rules := []string{}
+	for _, commit := range result.Commits {
+		rules = append(rules, commit.Rule)
+	}
+	assert.Equal(t, []string{
+		"breaking change (footer)",
+		"breaking change (!)",
+		"fix: patch",
+		"no mapping for type docs",
+		"not conventional: header is missing the ': ' separator between type and description",
+	}, rules)
+	assert.Equal(t, model.BumpMajor, result.Bump)
+	assert.Equal(t, result.Commits[0].Hash, result.BumpCause)
+	assert.Equal(t, headRef.Hash().String(), result.BaseCommit)
+}
+
+func TestFindNextVersion_VPrefixedTagFormat_FeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	opts := newOptions(t)
+	opts.TagFormat, err = model.ParseTagFormat("v{version}")
+	assert.NoError(t, err)
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "v1.1.0", result.Tag)
+}
+
+func TestFindNextVersion_CustomTagFormat_PatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("release/2.3.4", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.TagFormat, err = model.ParseTagFormat("release/{version}")
+	assert.NoError(t, err)
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "release/2.3.5", result.Tag)
+}
+
+func TestFindNextVersion_TagNotMatchingTagFormat(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	opts := newOptions(t)
+	opts.TagFormat, err = model.ParseTagFormat("v{version}")
+	assert.NoError(t, err)
+	result, err := findNextVersion(repo, opts)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindNextVersion_TagWithBuildMetadata_FeatCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.2.0+build.7", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.3.0", result.Tag)
+}
+
+func TestFindNextVersion_TagWithLeadingZeroIsInvalid(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.02.0", headRef.Hash(), nil)
+	assert.NoError(t, err)
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFindNextVersion_IgnoresTagOnUnrelatedBranch(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "next", true)
+	fakeCommit(t, repo, fs, "next.go", "feat!: next major")
+	tagHead(t, repo, "2.0.0")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.0.1", result.Tag)
+}
+
+func TestFindNextVersion_NearestTagWins(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	tagHead(t, repo, "1.1.0")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.1.1", result.Tag)
+}
+
+func TestFindNextVersion_NoReachableTag(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	checkoutBranch(t, repo, "next", true)
+	fakeCommit(t, repo, fs, "next.go", "feat: next feature")
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "0.0.1", result.Tag)
+	assert.Empty(t, result.PreviousTag)
+	assert.Equal(t, []string{"none of the 1 version tags is reachable, assuming no previous release"}, result.Warnings)
+}
+
+func TestFindNextVersion_NoReachableTag_ShallowClone(t *testing.T) {
+	t.Parallel()
+
+	repo := newShallowClone(t, 2, []string{"init", "feat: a", "fix: b", "fix: c", "fix: d", "fix: e"}, map[int]string{1: "1.0.0"})
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.ErrorContains(t, err, "shallow clone")
+	assert.Nil(t, result)
+}
+
+func TestFindNextVersion_MaintenanceBranch_PatchCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.4.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	tagHead(t, repo, "1.4.1")
+	checkoutBranch(t, repo, "release/1.4", true)
+	fakeCommit(t, repo, fs, "fix.go", "fix: fix another bug")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "feature.go", "feat!: breaking feature")
+	tagHead(t, repo, "2.0.0")
+	checkoutBranch(t, repo, "release/1.4", false)
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "release/*"}}
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.4.2", result.Tag)
+}
+
+func TestFindNextVersion_MaintenanceBranch_ExplicitRange(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.4.0")
+	checkoutBranch(t, repo, "hotfix", true)
+	fakeCommit(t, repo, fs, "util.go", "feat: some new feature")
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "main", Range: "2.x"}, {Pattern: "hotfix", Range: "1.x"}}
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "1.5.0", result.Tag)
+}
+
+func TestFindNextVersion_MaintenanceBranch_BumpOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.4.0")
+	checkoutBranch(t, repo, "release/1.4.x", true)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "release/*"}}
+	result, err := findNextVersion(repo, opts)
+
+	assert.ErrorContains(t, err, "minor bump to 1.5.0")
+	assert.Nil(t, result)
+}
+
+func TestFindNextVersion_MaintenanceBranch_BranchOverride(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.4.0")
+	fakeCommit(t, repo, fs, "main.go", "feat!: breaking feature")
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "release/*"}}
+	opts.Branch = "release/1.4"
+	result, err := findNextVersion(repo, opts)
+
+	assert.ErrorContains(t, err, "major bump to 2.0.0")
+	assert.Nil(t, result)
+}
+
+func TestFindNextVersion_InitialDevelopment(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		tag, message, expected string
+	}{
+		{"0.3.1", "feat!: breaking change", "0.4.0"},
+		{"0.3.1", "feat: some new feature", "0.3.2"},
+		{"0.3.1", "fix: fix a bug", "0.3.2"},
+		{"1.0.0", "feat!: breaking change", "2.0.0"},
+	} {
+		repo, fs := NewSimulatedRepository(t)
+		tagHead(t, repo, tc.tag)
+		fakeCommit(t, repo, fs, "main.go", tc.message)
+		opts := newOptions(t)
+		opts.InitialDevelopment = true
+		result, err := findNextVersion(repo, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, result.Tag, "%s on %s", tc.message, tc.tag)
+	}
+}
+
+func TestFindNextVersion_InitialDevelopment_NoTag(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "feat!: some new feature")
+	opts := newOptions(t)
+	opts.InitialDevelopment = true
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1.0", result.Tag)
+	assert.Equal(t, model.BumpMinor, result.Bump)
+	assert.Equal(t, "lowered from major during initial development", result.BumpNote)
+}
+
+func TestFindNextVersion_PromoteToStable(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "0.3.1")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.InitialDevelopment = true
+	opts.PromoteToStable = true
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", result.Tag)
+	assert.Equal(t, model.BumpMajor, result.Bump)
+	assert.Empty(t, result.BumpCause)
+	assert.Equal(t, "promoted to stable", result.BumpNote)
+}
+
+func TestFindNextVersion_PromoteToStable_AlreadyStable(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.2.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.PromoteToStable = true
+	_, err := findNextVersion(repo, opts)
+
+	assert.ErrorContains(t, err, "cannot promote 1.2.0 to stable, the major version is already 1")
+}
+
+func TestFindNextVersion_ReleaseAsFooter(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.2.3")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug\n\nRelease-As: 2.0.0")
+	releaseAs := headHash(t, repo)
+	fakeCommit(t, repo, fs, "util.go", "feat: some new feature\n\nRelease-As: 1.5.0")
+	fakeCommit(t, repo, fs, "cli.go", "fix: fix another bug\n\nRelease-As: 2.x")
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", result.Tag)
+	assert.Equal(t, model.BumpMajor, result.Bump)
+	assert.Equal(t, releaseAs, result.BumpCause)
+	assert.Equal(t, "Release-As 2.0.0", result.BumpNote)
+}
+
+func TestFindNextVersion_ReleaseAsFooter_NotHigher(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.2.3")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug\n\nRelease-As: 1.2.3")
+	_, err := findNextVersion(repo, newOptions(t))
+
+	assert.ErrorContains(t, err, "requests release 1.2.3, which is not higher than the previous version 1.2.3")
+}
+
+func TestFindNextVersion_BumpOverride(t *testing.T) {
+	t.Parallel()
+
+	for _, bump := range []model.Bump{model.BumpNone, model.BumpPatch, model.BumpMinor, model.BumpMajor} {
+		repo, fs := NewSimulatedRepository(t)
+		tagHead(t, repo, "1.2.3")
+		fakeCommit(t, repo, fs, "main.go", "feat: some new feature\n\nRelease-As: 3.0.0")
+		opts := newOptions(t)
+		opts.Bump = &bump
+		result, err := findNextVersion(repo, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[model.Bump]string{
+			model.BumpNone:  "1.2.3",
+			model.BumpPatch: "1.2.4",
+			model.BumpMinor: "1.3.0",
+			model.BumpMajor: "2.0.0",
+		}[bump], result.Tag)
+		assert.Equal(t, bump, result.Bump)
+		assert.Empty(t, result.BumpCause)
+	}
 }
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/StevenCyb/autosemver/internal/gomod"
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// FindGoModuleDirs returns the directories of the Go modules that are
+// evaluated with the options: the root module, or the modules of the paths.
+func FindGoModuleDirs(repositoryPath string, opts Options) ([]string, error) {
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := findEvaluatedGoModules(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(modules))
+	for _, module := range modules {
+		dirs = append(dirs, module.Dir)
+	}
+	return dirs, nil
+}
+
+func findEvaluatedGoModules(repo *git.Repository, opts Options) ([]goModule, error) {
+	modules, _, err := findGoModules(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	var evaluated []goModule
+	for _, module := range modules {
+		if len(opts.Paths) == 0 && module.Dir == "." ||
+			len(opts.Paths) > 0 && touchesAny(opts.Paths, opts.ExcludePaths, []string{path.Join(module.Dir, "go.mod")}) {
+			evaluated = append(evaluated, module)
+		}
+	}
+	return evaluated, nil
+}
+
+// checkGoModules verifies that a new release can be used by Go, which requires
+// the module path to end with the major version, e.g. "/v2", from 2 on.
+func checkGoModules(repo *git.Repository, result *Result, opts Options) error {
+	if opts.GoModuleCheck == "" || opts.GoModuleCheck == model.CheckOff || result.Bump == model.BumpNone {
+		return nil
+	}
+	modules, err := findEvaluatedGoModules(repo, opts)
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		if gomod.MatchesMajor(module.File.Module, result.Version.Major) {
+			continue
+		}
+		message := fmt.Sprintf("%s declares module %s, but version %s requires the module path %s (run 'autosemver gomod fix' to update it)",
+			path.Join(module.Dir, "go.mod"), module.File.Module, result.Version, gomod.PathForMajor(module.File.Module, result.Version.Major))
+		if opts.GoModuleCheck == model.CheckError {
+			return fmt.Errorf("%s", message)
+		}
+		opts.Log.Println("Warning: " + message)
+		result.Warnings = append(result.Warnings, message)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextVersion_GoModuleCheck(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/x\n", "feat: add module")
+	tagHead(t, repo, "1.2.0")
+	fakeCommit(t, repo, fs, "api.go", "feat!: change api")
+
+	opts := newOptions(t)
+	opts.GoModuleCheck = model.CheckError
+	_, err := findNextVersion(repo, opts)
+	assert.ErrorContains(t, err, "go.mod declares module example.com/x, but version 2.0.0 requires the module path example.com/x/v2")
+
+	opts.GoModuleCheck = model.CheckWarn
+	result, err := findNextVersion(repo, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", result.Tag)
+	assert.Len(t, result.Warnings, 1)
+
+	opts.GoModuleCheck = model.CheckOff
+	result, err = findNextVersion(repo, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestFindNextVersion_GoModuleCheck_MatchingPath(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/x/v2\n", "feat!: add module v2")
+	tagHead(t, repo, "2.0.0")
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/x/api\n", "fix: add api module")
+	opts := newOptions(t)
+	opts.GoModuleCheck = model.CheckError
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.1", result.Tag)
+}
+
+func TestFindComponentVersions_GoModuleCheck(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/api\n", "feat: add api module")
+	tagHead(t, repo, "api/v1.0.0")
+	fakeCommit(t, repo, fs, "api/api.go", "feat!: change api")
+	components, err := findGoModuleComponents(repo, newOptions(t))
+	assert.NoError(t, err)
+	opts := newOptions(t)
+	opts.GoModuleCheck = model.CheckError
+	_, err = findComponentVersions(repo, components, "", opts)
+
+	assert.ErrorContains(t, err, "component api: api/go.mod declares module example.com/api, but version 2.0.0 requires the module path example.com/api/v2")
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func FindReleaseHistory(repositoryPath string, opts Options) ([]Release, error) {
+	opts.Log.Printf("Finding release history in %s\n", repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findReleaseHistory(repo, opts)
+}
+
+// findReleaseHistory returns every release reachable from HEAD, latest first,
+// with the commits since the release(s) preceding it.
+func findReleaseHistory(repo *git.Repository, opts Options) ([]Release, error) {
+	log := opts.Log
+	tags, err := findVersionTags(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	versionRange, err := findBranchRange(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	head, err := resolveHead(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := readNotes(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := tagsByHash(tags, releaseInRange(versionRange))
+	var releaseTags []versionTag
+	err = walkUntilTagged(repo, head, map[plumbing.Hash][]versionTag{}, func(c *object.Commit, _ []versionTag) {
+		releaseTags = append(releaseTags, tagged[c.Hash]...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(releaseTags, func(i, j int) bool {
+		return releaseTags[j].Version.LessThan(releaseTags[i].Version)
+	})
+
+	releases := make([]Release, 0, len(releaseTags))
+	for _, tag := range releaseTags {
+		log.Printf("Finding commits of release %s\n", tag.Name)
+		tagCommit, err := repo.CommitObject(plumbing.NewHash(tag.Hash))
+		if err != nil {
+			return nil, err
+		}
+
+		previous := make(map[plumbing.Hash][]versionTag, len(tagged))
+		for hash, tags := range tagged {
+			if hash != tagCommit.Hash {
+				previous[hash] = tags
+			}
+		}
+		var bases []plumbing.Hash
+		err = walkUntilTagged(repo, tagCommit.Hash, previous, func(c *object.Commit, tags []versionTag) {
+			if len(tags) > 0 {
+				bases = append(bases, c.Hash)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		commits, err := collectCommitsFrom(repo, tagCommit.Hash, bases, opts.FirstParent)
+		if err != nil {
+			return nil, err
+		}
+		commits, err = filterCommitsByPaths(commits, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		releases = append(releases, Release{
+			Tag:     tag.Name,
+			Version: tag.Version,
+			Date:    tagCommit.Committer.When,
+			Commits: analyzeCommits(commits, notes, opts),
+		})
+	}
+	return releases, nil
+}
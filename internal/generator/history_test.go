@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindReleaseHistory(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	tagHead(t, repo, "1.1.0")
+	fakeCommit(t, repo, fs, "other.go", "fix: fix another bug")
+	tagHead(t, repo, "1.1.1")
+	tagHead(t, repo, "1.2.0-rc.1")
+	fakeCommit(t, repo, fs, "next.go", "feat: unreleased feature")
+	releases, err := findReleaseHistory(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, releases, 3)
+	assert.Equal(t, "1.1.1", releases[0].Tag)
+	assert.Len(t, releases[0].Commits, 1)
+	assert.Equal(t, "fix: fix another bug", releases[0].Commits[0].Subject)
+	assert.Equal(t, "1.1.0", releases[1].Tag)
+	assert.Len(t, releases[1].Commits, 2)
+	assert.Equal(t, "fix: fix a bug", releases[1].Commits[0].Subject)
+	assert.Equal(t, "feat: some new feature", releases[1].Commits[1].Subject)
+	assert.Equal(t, "1.0.0", releases[2].Tag)
+	assert.Len(t, releases[2].Commits, 1)
+	assert.Equal(t, "init", releases[2].Commits[0].Subject)
+}
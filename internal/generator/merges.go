@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var mergeBranchPatterns = []*regexp.Regexp{
+	// GitHub, the branch is prefixed with the owner of the fork.
+	regexp.MustCompile(`^Merge pull request #\d+ from [^/\s]+/(\S+)`),
+	// git merge and GitLab.
+	regexp.MustCompile(`^Merge (?:remote-tracking )?branch '(?:origin/)?([^']+)'`),
+	// Bitbucket.
+	regexp.MustCompile(`^Merged in (\S+)`),
+}
+var listItemPattern = regexp.MustCompile(`^[*-] +(\S.*)$`)
+
+// commitMessage is a message evaluated for a commit, a commit may result in
+// several messages, e.g. the listed commits of a squash commit.
+type commitMessage struct {
+	raw string
+	// source is appended to the rule of the message, e.g. " (squashed)".
+	source string
+	skip   bool
+}
+
+// commitMessages returns the messages to evaluate for the commit according
+// to the merge strategy and squash commit options.
+func commitMessages(c *object.Commit, opts Options) []commitMessage {
+	if c.NumParents() > 1 {
+		switch opts.MergeStrategy {
+		case model.MergeSkip:
+			return []commitMessage{{raw: c.Message, source: " (merge commit)", skip: true}}
+		case model.MergeTitle:
+			if _, body, ok := strings.Cut(c.Message, "\n"); ok && strings.TrimSpace(body) != "" {
+				return []commitMessage{{raw: strings.TrimSpace(body), source: " (merge title)"}}
+			}
+		case model.MergeBranch:
+			if message, ok := branchMessage(c.Message); ok {
+				return []commitMessage{{raw: message, source: " (merge branch)"}}
+			}
+		case model.MergeCommits:
+			messages := []commitMessage{{raw: c.Message, source: " (merge commit)", skip: true}}
+			for _, item := range listedCommits(c.Message) {
+				messages = append(messages, commitMessage{raw: item, source: " (merged)"})
+			}
+			return messages
+		}
+		return []commitMessage{{raw: c.Message}}
+	}
+
+	messages := []commitMessage{{raw: c.Message}}
+	if opts.SquashCommits {
+		for _, item := range listedCommits(c.Message) {
+			messages = append(messages, commitMessage{raw: item, source: " (squashed)"})
+		}
+	}
+	return messages
+}
+
+// branchMessage derives a commit message from the merged branch name, e.g.
+// "feat: add foo" from feat/add-foo.
+func branchMessage(message string) (string, bool) {
+	subject := strings.SplitN(message, "\n", 2)[0]
+	for _, pattern := range mergeBranchPatterns {
+		match := pattern.FindStringSubmatch(subject)
+		if match == nil {
+			continue
+		}
+		commitType, description, ok := strings.Cut(match[1], "/")
+		if !ok || description == "" {
+			return "", false
+		}
+		description = strings.NewReplacer("-", " ", "_", " ", "/", " ").Replace(description)
+		return commitType + ": " + description, true
+	}
+	return "", false
+}
+
+// listedCommits returns the commits listed as "* " or "- " items in the body
+// of the message, each with the lines following it as its body.
+func listedCommits(message string) []string {
+	_, body, _ := strings.Cut(message, "\n")
+	var items [][]string
+	for _, line := range strings.Split(body, "\n") {
+		if match := listItemPattern.FindStringSubmatch(line); match != nil {
+			items = append(items, []string{match[1], ""})
+		} else if len(items) > 0 {
+			items[len(items)-1] = append(items[len(items)-1], line)
+		}
+	}
+	commits := make([]string, 0, len(items))
+	for _, item := range items {
+		body := strings.TrimSpace(strings.Join(item[1:], "\n"))
+		if body == "" {
+			commits = append(commits, item[0])
+		} else {
+			commits = append(commits, item[0]+"\n\n"+body)
+		}
+	}
+	return commits
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchMessage(t *testing.T) {
+	t.Parallel()
+
+	for message, expected := range map[string]string{
+		"Merge pull request #42 from org/feat/add-foo\n\nAdd foo": "feat: add foo",
+		"Merge branch 'fix/null_pointer' into 'main'":             "fix: null pointer",
+		"Merge remote-tracking branch 'origin/feat/api/v2'":       "feat: api v2",
+		"Merged in perf/cache (pull request #7)":                  "perf: cache",
+		"Merge branch 'develop'":                                  "",
+		"Merge pull request #42 from org/develop":                 "",
+		"feat: not a merge":                                       "",
+	} {
+		actual, ok := branchMessage(message)
+
+		assert.Equal(t, expected != "", ok, message)
+		assert.Equal(t, expected, actual, message)
+	}
+}
+
+func TestListedCommits(t *testing.T) {
+	t.Parallel()
+
+	commits := listedCommits(`Add foo (#42)
+
+* feat(api): add foo
+
+  Adds the foo endpoint.
+
+* fix: handle empty foo
+- refactor!: rename bar
+
+BREAKING CHANGE: bar is now baz
+`)
+
+	assert.Equal(t, []string{
+		"feat(api): add foo\n\nAdds the foo endpoint.",
+		"fix: handle empty foo",
+		"refactor!: rename bar\n\nBREAKING CHANGE: bar is now baz",
+	}, commits)
+}
+
+// newMergeRepository creates a repository tagged 1.0.0 with a docs commit on
+// main and the branch feat/add-foo merged into it.
+func newMergeRepository(t *testing.T, message string) (*git.Repository, billy.Filesystem) {
+	t.Helper()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "feat/add-foo", true)
+	fakeCommit(t, repo, fs, "foo.go", "fix: fix foo")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "docs: update readme")
+	fakeMergeCommit(t, repo, fs, "merge.go", "feat/add-foo", message)
+	return repo, fs
+}
+
+func TestFindNextVersion_MergeStrategies(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		strategy model.MergeStrategy
+		message  string
+		expected string
+		rules    []string
+	}{
+		{model.MergeMessage, "Merge pull request #42 from org/feat/add-foo\n\nfeat: add foo", "1.0.0",
+			[]string{"not conventional: header is missing the ': ' separator between type and description", "no mapping for type docs"}},
+		{model.MergeSkip, "feat!: merge foo", "1.0.0",
+			[]string{"skipped (merge commit)", "no mapping for type docs"}},
+		{model.MergeTitle, "Merge pull request #42 from org/feat/add-foo\n\nfeat: add foo", "1.1.0",
+			[]string{"feat: minor (merge title)", "no mapping for type docs"}},
+		{model.MergeBranch, "Merge pull request #42 from org/feat/add-foo\n\nAdd foo", "1.1.0",
+			[]string{"feat: minor (merge branch)", "no mapping for type docs"}},
+		{model.MergeCommits, "Merge pull request #42 from org/feat/add-foo\n\n* feat: add foo\n* fix!: fix foo", "2.0.0",
+			[]string{"skipped (merge commit)", "feat: minor (merged)", "breaking change (!) (merged)", "no mapping for type docs"}},
+	} {
+		repo, _ := newMergeRepository(t, tc.message)
+		opts := newOptions(t)
+		opts.MergeStrategy = tc.strategy
+		// Only the merge commit is evaluated, not the commits of the merged branch.
+		opts.FirstParent = true
+		result, err := findNextVersion(repo, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, result.Tag, tc.strategy)
+		rules := []string{}
+		for _, commit := range result.Commits {
+			rules = append(rules, commit.Rule)
+		}
+		assert.Equal(t, tc.rules, rules, tc.strategy)
+	}
+}
+
+func TestFindNextVersion_SquashCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "Add foo (#42)\n\n* feat: add foo\n\n* fix: handle empty foo")
+	opts := newOptions(t)
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", result.Tag)
+
+	opts.SquashCommits = true
+	result, err = findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Len(t, result.Commits, 3)
+	assert.Equal(t, "feat: add foo", result.Commits[1].Subject)
+	assert.Equal(t, "feat: minor (squashed)", result.Commits[1].Rule)
+	assert.Equal(t, result.Commits[0].Hash, result.Commits[1].Hash)
+	assert.Equal(t, "fix: patch (squashed)", result.Commits[2].Rule)
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// RootModuleName is the component name of a Go module in the repository root.
+const RootModuleName = "root"
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// FindGoModules returns a component for every Go module of the repository, the
+// ones used by the go.work file in the root or, without one, all directories
+// with a go.mod file. Modules are tagged the way the Go toolchain expects:
+// "v{version}" in the root and "<dir>/v{version}" in subdirectories.
+func FindGoModules(repositoryPath string, opts Options) ([]model.Component, error) {
+	opts.Log.Printf("Finding Go modules in %s\n", repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findGoModuleComponents(repo, opts)
+}
+
+func findGoModuleComponents(repo *git.Repository, opts Options) ([]model.Component, error) {
+	modules, workspace, err := findGoModules(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Dir < modules[j].Dir
+	})
+
+	var components []model.Component
+	for _, module := range modules {
+		if workspace != nil && !workspace[module.Dir] {
+			opts.Log.Printf("Module %s in %s is not used by go.work, ignoring\n", module.File.Module, module.Dir)
+			continue
+		}
+
+		component := model.Component{Name: module.Dir, Paths: []string{module.Dir}}
+		if module.Dir == "." {
+			component.Name = RootModuleName
+			component.Paths = []string{"**"}
+		}
+		// Nested modules are not part of the module, even if they are not used
+		// by the go.work file.
+		for _, nested := range modules {
+			if nested.Dir != module.Dir && (module.Dir == "." || strings.HasPrefix(nested.Dir, module.Dir+"/")) {
+				component.Exclude = append(component.Exclude, nested.Dir)
+			}
+		}
+		component.TagFormat = goModuleTagFormat(module)
+		if err := component.Validate(); err != nil {
+			return nil, fmt.Errorf("module %s: %w", module.File.Module, err)
+		}
+		opts.Log.Printf("Found module %s in %s tagged as %s\n", module.File.Module, module.Dir, component.TagFormat)
+		components = append(components, component)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("no Go modules found")
+	}
+	return components, nil
+}
+
+// goModuleTagFormat returns the tag format of the module. The major version
+// directory of modules like "example.com/api/v2" in "api/v2" is not part of
+// the tag, which is "api/v2.0.0".
+func goModuleTagFormat(module goModule) model.TagFormat {
+	dir := module.Dir
+	if base := path.Base(dir); majorVersionSuffix.MatchString(base) && strings.HasSuffix(module.File.Module, "/"+base) {
+		dir = path.Dir(dir)
+	}
+	if dir == "." {
+		return model.TagFormat{Prefix: "v"}
+	}
+	return model.TagFormat{Prefix: dir + "/v"}
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindGoModuleComponents(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/repo\n", "chore: add root module")
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/repo/api\n", "chore: add api module")
+	fakeCommitContent(t, repo, fs, "api/v2/go.mod", "module example.com/repo/api/v2\n", "chore: add api v2 module")
+	fakeCommitContent(t, repo, fs, "tools/go.mod", "module example.com/repo/tools\n", "chore: add tools module")
+	components, err := findGoModuleComponents(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Component{
+		{Name: "root", Paths: []string{"**"}, Exclude: []string{"api", "api/v2", "tools"}, TagFormat: model.TagFormat{Prefix: "v"}},
+		{Name: "api", Paths: []string{"api"}, Exclude: []string{"api/v2"}, TagFormat: model.TagFormat{Prefix: "api/v"}},
+		{Name: "api/v2", Paths: []string{"api/v2"}, TagFormat: model.TagFormat{Prefix: "api/v"}},
+		{Name: "tools", Paths: []string{"tools"}, TagFormat: model.TagFormat{Prefix: "tools/v"}},
+	}, components)
+}
+
+func TestFindGoModuleComponents_Workspace(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.work", "go 1.23\n\nuse ./api\n", "chore: add workspace")
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/api\n", "chore: add api module")
+	fakeCommitContent(t, repo, fs, "testdata/go.mod", "module example.com/testdata\n", "chore: add test module")
+	components, err := findGoModuleComponents(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, components, 1)
+	assert.Equal(t, "api", components[0].Name)
+}
+
+func TestFindGoModuleComponents_NoModules(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	_, err := findGoModuleComponents(repo, newOptions(t))
+
+	assert.ErrorContains(t, err, "no Go modules found")
+}
+
+func TestFindComponentVersions_GoModules(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommitContent(t, repo, fs, "go.mod", "module example.com/repo\n", "feat: add root module")
+	fakeCommitContent(t, repo, fs, "api/go.mod", "module example.com/repo/api\n", "feat: add api module")
+	tagHead(t, repo, "v1.0.0")
+	tagHead(t, repo, "api/v1.4.0")
+	fakeCommit(t, repo, fs, "api/handler.go", "fix: fix handler")
+	components, err := findGoModuleComponents(repo, newOptions(t))
+	assert.NoError(t, err)
+	results, err := findComponentVersions(repo, components, "", newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", results[0].Result.Tag)
+	assert.Equal(t, "api/v1.4.1", results[1].Result.Tag)
+}
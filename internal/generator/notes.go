@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// NotesRef is the git notes reference holding commit message overrides, e.g.
+// added with `git notes --ref=autosemver add -m "feat: x" <commit>`.
+const NotesRef = "refs/notes/autosemver"
+
+// readNotes returns the notes of NotesRef by commit hash.
+func readNotes(repo *git.Repository) (map[string]string, error) {
+	notes := map[string]string{}
+	ref, err := repo.Reference(plumbing.ReferenceName(NotesRef), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return notes, nil
+	} else if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	// Notes are stored as blobs named by the annotated commit hash, which git
+	// splits into fanout directories like "ab/cdef..." in large note trees.
+	err = tree.Files().ForEach(func(f *object.File) error {
+		hash := strings.ReplaceAll(f.Name, "/", "")
+		if !plumbing.IsHash(hash) {
+			return nil
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		notes[hash] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadNotes(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	first := headHash(t, repo)
+	fakeCommit(t, repo, fs, "main.go", "fix: a")
+	second := headHash(t, repo)
+	writeNotes(t, repo, map[string]string{
+		first:                         "feat: x\n",
+		second[:2] + "/" + second[2:]: "fix: y\n",
+		"README":                      "not a note",
+	})
+
+	notes, err := readNotes(repo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{first: "feat: x\n", second: "fix: y\n"}, notes)
+}
+
+func TestReadNotes_NoNotes(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	notes, err := readNotes(repo)
+
+	assert.NoError(t, err)
+	assert.Empty(t, notes)
+}
+
+func TestFindNextVersion_NoteOverridesCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "feat(api) missing colon")
+	mistyped := headHash(t, repo)
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	writeNotes(t, repo, map[string]string{mistyped: "feat(api): new endpoint\n"})
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Equal(t, mistyped, result.BumpCause)
+	assert.Equal(t, "feat(api): new endpoint", result.Commits[1].Subject)
+	assert.Equal(t, "feat: minor (git note)", result.Commits[1].Rule)
+}
+
+func TestFindNextVersion_NoteIgnoresCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "feat!: not actually breaking")
+	writeNotes(t, repo, map[string]string{headHash(t, repo): "chore: not actually breaking"})
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", result.Tag)
+	assert.Equal(t, model.BumpNone, result.Bump)
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+type Options struct {
+	IncMapping        []model.Tuple[string, model.Bump]
+	Log               logger.Logger
+	IgnoreInvalidTags bool
+	TagFormat         model.TagFormat
+	BranchRules       []model.BranchRule
+	Branch            string
+	// Paths restricts the evaluated commits to those changing a matching file.
+	Paths []string
+	// ExcludePaths ignores changes of matching files when filtering by Paths.
+	ExcludePaths []string
+	// IgnoreForeignTags skips tags that are not a version in TagFormat instead
+	// of treating them as invalid, e.g. tags of other components in a monorepo.
+	IgnoreForeignTags bool
+	// MinBump is the minimum bump of the next version, e.g. a patch bump if a
+	// dependency is released.
+	MinBump model.Bump
+	// DiscoverDependencies derives dependencies between components from the
+	// go.mod and go.work files at HEAD.
+	DiscoverDependencies bool
+	// GoModuleCheck checks that the module paths of the evaluated Go modules
+	// match the major version of a new release. Unset means off.
+	GoModuleCheck model.CheckLevel
+	// APICheck compares the exported API of the evaluated Go modules at the
+	// previous release and HEAD, and checks that the bump is sufficient for
+	// the changes. Unset means off.
+	APICheck model.CheckLevel
+	// InitialDevelopment lowers the bump while the major version is 0, a
+	// breaking change bumps the minor and a minor bump the patch version.
+	InitialDevelopment bool
+	// PromoteToStable releases 1.0.0 if the major version is 0.
+	PromoteToStable bool
+	// Bump overrides the bump computed from the commits if set.
+	Bump *model.Bump
+	// MergeStrategy controls how merge commits are evaluated. Unset means
+	// model.MergeMessage.
+	MergeStrategy model.MergeStrategy
+	// SquashCommits evaluates every commit listed in the body of a commit,
+	// e.g. "* feat: x" of a squash merge, in addition to the commit itself.
+	SquashCommits bool
+	// FirstParent only follows the first parent of merge commits when
+	// collecting the commits since the previous release.
+	FirstParent bool
+	// Ref is the evaluated revision instead of HEAD.
+	Ref string
+	// From is the base revision of the evaluated commits instead of the
+	// latest release, whose version is the nearest release reachable from it.
+	From string
+	// Target predicts the release after merging Ref (or HEAD) into the
+	// target revision, e.g. the base branch of a pull request.
+	Target string
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// MatchGlob reports whether the slash separated file name or one of its parent
+// directories matches the pattern. Besides the syntax of path.Match, a "**"
+// segment matches any number of directories.
+func MatchGlob(pattern, name string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFiles returns the files changed by the commit compared to its first
+// parent, or all files for a root commit.
+func changedFiles(c *object.Commit) ([]string, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.From.Name != "" {
+			files = append(files, change.From.Name)
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			files = append(files, change.To.Name)
+		}
+	}
+	return files, nil
+}
+
+func filterCommitsByPaths(commits []*object.Commit, opts Options) ([]*object.Commit, error) {
+	if len(opts.Paths) == 0 {
+		return commits, nil
+	}
+
+	filtered := make([]*object.Commit, 0, len(commits))
+	for _, c := range commits {
+		files, err := changedFiles(c)
+		if err != nil {
+			return nil, err
+		}
+		if touchesAny(opts.Paths, opts.ExcludePaths, files) {
+			filtered = append(filtered, c)
+		} else {
+			opts.Log.Printf("Commit %s does not touch %s, ignoring\n", c.Hash.String(), strings.Join(opts.Paths, ", "))
+		}
+	}
+	return filtered, nil
+}
+
+func touchesAny(patterns, exclude []string, files []string) bool {
+	for _, file := range files {
+		if matchAny(patterns, file) && !matchAny(exclude, file) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"services/a", "services/a/main.go", true},
+		{"services/a/", "services/a/cmd/main.go", true},
+		{"services/a", "services/ab/main.go", false},
+		{"services/*", "services/b/main.go", true},
+		{"services/**/*.go", "services/a/cmd/main.go", true},
+		{"services/**/*.go", "services/main.go", true},
+		{"services/**/*.go", "services/a/README.md", false},
+		{"**/go.mod", "go.mod", true},
+		{"**/go.mod", "libs/x/go.mod", true},
+		{"*.md", "README.md", true},
+		{"*.md", "docs/README.md", false},
+		{"libs/x/go.mod", "libs/x", false},
+	} {
+		assert.Equal(t, tc.match, MatchGlob(tc.pattern, tc.name), "%s ~ %s", tc.pattern, tc.name)
+	}
+}
+
+func TestFindNextVersion_Paths(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "services/a/main.go", "fix: fix a")
+	fakeCommit(t, repo, fs, "services/b/main.go", "feat: feature of b")
+	opts := newOptions(t)
+	opts.Paths = []string{"services/a"}
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Len(t, result.Commits, 1)
+	assert.Equal(t, "fix: fix a", result.Commits[0].Subject)
+}
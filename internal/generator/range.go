@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// FindCommits returns the commits reachable from the revision to but not from
+// the revision from, like `git log from..to`. An empty to means HEAD and an
+// empty from the latest release reachable from to.
+func FindCommits(repositoryPath, from, to string, opts Options) ([]Commit, error) {
+	opts.Log.Printf("Finding commits in %s\n", repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findCommits(repo, from, to, opts)
+}
+
+func findCommits(repo *git.Repository, from, to string, opts Options) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	toHash, err := resolveRevision(repo, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var bases []plumbing.Hash
+	if from == "" {
+		tags, err := findVersionTags(repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		latestVersionTag, _, err := findNearestTag(repo, []plumbing.Hash{toHash}, tags, isRelease, opts.Log)
+		if err != nil {
+			return nil, err
+		}
+		if latestVersionTag != nil {
+			bases = append(bases, plumbing.NewHash(latestVersionTag.Hash))
+		}
+	} else {
+		fromHash, err := resolveRevision(repo, from)
+		if err != nil {
+			return nil, err
+		}
+		bases = append(bases, fromHash)
+	}
+
+	// The commits are returned as they are, e.g. to lint their messages.
+	opts.MergeStrategy, opts.SquashCommits = model.MergeMessage, false
+	commits, err := collectCommitsFrom(repo, toHash, bases, opts.FirstParent)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := readNotes(repo)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeCommits(commits, notes, opts), nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCommits(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "a.go", "feat: a")
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "b.go", "fix b")
+	tagHead(t, repo, "pending")
+	fakeCommit(t, repo, fs, "c.go", "fix: c\n\nbody")
+	opts := newOptions(t)
+	opts.IgnoreInvalidTags = true
+
+	commits, err := findCommits(repo, "", "", opts)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+	assert.Equal(t, "fix: c\n\nbody", commits[0].Raw)
+	assert.Equal(t, "fix b", commits[1].Subject)
+
+	commits, err = findCommits(repo, "1.0.0", "pending", opts)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, "fix b", commits[0].Subject)
+
+	_, err = findCommits(repo, "unknown", "", opts)
+	assert.ErrorContains(t, err, "cannot resolve revision unknown")
+}
+
+func TestFindCommits_IgnoresVersionErrors(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "a.go", "feat: a")
+	tagHead(t, repo, "1.4.0")
+	checkoutBranch(t, repo, "release/1.4", true)
+	fakeCommit(t, repo, fs, "b.go", "feat!: b")
+	opts := newOptions(t)
+	opts.BranchRules = []model.BranchRule{{Pattern: "release/*"}}
+
+	commits, err := findCommits(repo, "", "", opts)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, "feat!: b", commits[0].Subject)
+}
+
+func TestFindCommits_BaseReachableFromTo(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "a.go", "feat: a")
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "b.go", "fix: b")
+	to := headHash(t, repo)
+	fakeCommit(t, repo, fs, "c.go", "fix: c")
+	tagHead(t, repo, "1.0.1")
+	fakeCommit(t, repo, fs, "d.go", "fix: d")
+
+	commits, err := findCommits(repo, "", to, newOptions(t))
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, "fix: b", commits[0].Subject)
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// resolveHead returns the evaluated commit, which is Options.Ref or HEAD.
+func resolveHead(repo *git.Repository, opts Options) (plumbing.Hash, error) {
+	if opts.Ref != "" {
+		return resolveRevision(repo, opts.Ref)
+	}
+	headRef, err := repo.Head()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return headRef.Hash(), nil
+}
+
+func resolveRevision(repo *git.Repository, revision string) (plumbing.Hash, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve revision %s: %w", revision, err)
+	}
+	return *hash, nil
+}
+
+// resolveHeads returns the evaluated commit and, when predicting a merge, the
+// head of Options.Target.
+func resolveHeads(repo *git.Repository, opts Options) ([]plumbing.Hash, error) {
+	head, err := resolveHead(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Target == "" {
+		return []plumbing.Hash{head}, nil
+	}
+	target, err := resolveRevision(repo, opts.Target)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, err
+	}
+	targetCommit, err := repo.CommitObject(target)
+	if err != nil {
+		return nil, err
+	}
+	mergeBases, err := headCommit.MergeBase(targetCommit)
+	if err != nil {
+		return nil, err
+	}
+	if len(mergeBases) == 0 {
+		return nil, fmt.Errorf("%s and %s have no common history", head, opts.Target)
+	}
+	opts.Log.Printf("Predicting merge of %s into %s with merge base %s\n", head, opts.Target, mergeBases[0].Hash)
+	return []plumbing.Hash{head, target}, nil
+}
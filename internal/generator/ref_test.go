@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextVersion_Ref(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	fix := headHash(t, repo)
+	fakeCommit(t, repo, fs, "feature.go", "feat: some new feature")
+	opts := newOptions(t)
+	opts.Ref = fix[:7]
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Equal(t, fix, result.HeadCommit)
+	assert.Len(t, result.Commits, 1)
+}
+
+func TestFindNextVersion_RefAndFrom_HistoricalRelease(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	tagHead(t, repo, "1.1.0")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.Ref = "1.1.0"
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Empty(t, result.Commits)
+
+	opts.From = "1.0.0"
+	result, err = findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Equal(t, "1.0.0", result.PreviousTag)
+	assert.Len(t, result.Commits, 1)
+}
+
+func TestFindNextVersion_From(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "feature.go", "feat: some new feature")
+	feat := headHash(t, repo)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	opts := newOptions(t)
+	opts.From = feat
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.Equal(t, "1.0.0", result.PreviousTag)
+	assert.Equal(t, feat, result.BaseCommit)
+	assert.Len(t, result.Commits, 1)
+}
+
+func TestFindNextVersion_Target(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	checkoutBranch(t, repo, "feature", true)
+	fakeCommit(t, repo, fs, "feature.go", "feat: some new feature")
+	checkoutBranch(t, repo, "main", false)
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	tagHead(t, repo, "1.0.1")
+	fakeCommit(t, repo, fs, "docs.go", "docs: update readme")
+	opts := newOptions(t)
+	opts.Ref = "feature"
+	opts.Target = "main"
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Equal(t, "1.0.1", result.PreviousTag)
+	subjects := []string{}
+	for _, commit := range result.Commits {
+		subjects = append(subjects, commit.Subject)
+	}
+	assert.Equal(t, []string{"feat: some new feature", "docs: update readme"}, subjects)
+}
+
+func TestFindNextVersion_UnknownRef(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	opts := newOptions(t)
+	opts.Ref = "unknown"
+	_, err := findNextVersion(repo, opts)
+
+	assert.ErrorContains(t, err, "cannot resolve revision unknown")
+}
+
+func TestCurrentBranch_Ref(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := NewSimulatedRepository(t)
+	checkoutBranch(t, repo, "release/1.4", true)
+	checkoutBranch(t, repo, "main", false)
+
+	for ref, expected := range map[string]string{"release/1.4": "release/1.4", headHash(t, repo): "", "": "main"} {
+		opts := newOptions(t)
+		opts.Ref = ref
+		branch, err := currentBranch(repo, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, branch, ref)
+	}
+	opts := newOptions(t)
+	opts.Ref, opts.Target = "release/1.4", "main"
+	branch, err := currentBranch(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch)
+}
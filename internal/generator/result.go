@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+)
+
+type Commit struct {
+	Hash    string
+	Subject string
+	Raw     string
+	Date    time.Time
+	// Message is nil if the commit is not a conventional commit.
+	Message *conventional.Commit
+	Bump    model.Bump
+	// Rule describes why the commit results in Bump, e.g. "feat: minor".
+	Rule string
+	// ReleaseAs is the version requested by a Release-As footer.
+	ReleaseAs *semver.SemVer
+	// Reverts are the (possibly abbreviated) hashes of reverted commits.
+	Reverts []string
+	// Cancelled is set for a commit reverted by another one of the commits,
+	// and for the commit reverting it.
+	Cancelled bool
+}
+
+type Result struct {
+	PreviousTag     string
+	PreviousVersion *semver.SemVer
+	Tag             string
+	Version         semver.SemVer
+	Bump            model.Bump
+	// BumpCause is the hash of the commit determining Bump, if any.
+	BumpCause string
+	// BumpNote describes an adjustment of Bump not caused by a commit, e.g.
+	// "raised to the minimum bump".
+	BumpNote   string
+	BaseCommit string
+	HeadCommit string
+	Date       time.Time
+	Commits    []Commit
+	Warnings   []string
+	// Distance is the number of commits since the base commit, counting only
+	// commits that change the paths of a component.
+	Distance int
+	// Dirty is set by snapshots if the worktree has uncommitted changes.
+	Dirty bool
+}
+
+type Release struct {
+	Tag     string
+	Version semver.SemVer
+	Date    time.Time
+	Commits []Commit
+}
+
+func (r *Result) Release() Release {
+	return Release{Tag: r.Tag, Version: r.Version, Date: r.Date, Commits: r.Commits}
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+var revertPattern = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-fA-F]{7,40})\b`)
+var abbreviatedHashPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// findReverted returns the (possibly abbreviated) hashes of the commits
+// reverted by the commit, from the "This reverts commit <hash>" line of git
+// revert or the Refs footer of a conventional revert commit.
+func findReverted(commit *Commit) []string {
+	var hashes []string
+	for _, match := range revertPattern.FindAllStringSubmatch(commit.Raw, -1) {
+		hashes = append(hashes, strings.ToLower(match[1]))
+	}
+	if commit.Message != nil && strings.EqualFold(commit.Message.Type, "revert") {
+		if refs, ok := commit.Message.Footer("Refs"); ok {
+			for _, ref := range strings.Split(refs, ",") {
+				if ref = strings.TrimSpace(ref); abbreviatedHashPattern.MatchString(ref) {
+					hashes = append(hashes, strings.ToLower(ref))
+				}
+			}
+		}
+	}
+	return hashes
+}
+
+// cancelReverts cancels out reverted commits and the commits reverting them
+// if both are part of the commits, so they do not contribute to the bump. The
+// commits are expected newest first, so a reverted revert restores the
+// originally reverted commit.
+func cancelReverts(commits []Commit, log logger.Logger) {
+	for i := range commits {
+		revert := &commits[i]
+		if revert.Cancelled {
+			continue
+		}
+		for _, hash := range revert.Reverts {
+			reverted := findRevertedCommit(commits[i+1:], hash)
+			if reverted == nil {
+				continue
+			}
+			log.Printf("Commit %s reverts commit %s, ignoring both\n", revert.Hash, reverted.Hash)
+			revert.Cancelled, reverted.Cancelled = true, true
+			revert.Bump, reverted.Bump = model.BumpNone, model.BumpNone
+			revert.Rule = "reverts " + reverted.Hash[:7]
+			reverted.Rule = "reverted by " + revert.Hash[:7]
+			break
+		}
+	}
+}
+
+func findRevertedCommit(commits []Commit, hash string) *Commit {
+	for i := range commits {
+		if !commits[i].Cancelled && strings.HasPrefix(commits[i].Hash, hash) {
+			return &commits[i]
+		}
+	}
+	return nil
+}
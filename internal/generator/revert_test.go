@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNextVersion_GitRevertCancelsCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	fakeCommit(t, repo, fs, "feature.go", "feat: x")
+	feat := headHash(t, repo)
+	fakeCommit(t, repo, fs, "feature_revert.go", fmt.Sprintf("Revert \"feat: x\"\n\nThis reverts commit %s.", feat))
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.True(t, result.Commits[0].Cancelled)
+	assert.Equal(t, "reverts "+feat[:7], result.Commits[0].Rule)
+	assert.True(t, result.Commits[1].Cancelled)
+	assert.Equal(t, model.BumpNone, result.Commits[1].Bump)
+	assert.Equal(t, "reverted by "+result.Commits[0].Hash[:7], result.Commits[1].Rule)
+	assert.False(t, result.Commits[2].Cancelled)
+}
+
+func TestFindNextVersion_ConventionalRevertCancelsCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "feature.go", "feat!: x")
+	feat := headHash(t, repo)
+	fakeCommit(t, repo, fs, "feature_revert.go", fmt.Sprintf("revert: x\n\nRefs: %s", feat[:7]))
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", result.Tag)
+	assert.Equal(t, model.BumpNone, result.Bump)
+}
+
+func TestFindNextVersion_RevertedRevertRestoresCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "feature.go", "feat: x")
+	feat := headHash(t, repo)
+	fakeCommit(t, repo, fs, "feature_revert.go", fmt.Sprintf("Revert \"feat: x\"\n\nThis reverts commit %s.", feat))
+	revert := headHash(t, repo)
+	fakeCommit(t, repo, fs, "feature_reapply.go", fmt.Sprintf("Revert \"Revert \"feat: x\"\"\n\nThis reverts commit %s.", revert))
+	result, err := findNextVersion(repo, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+	assert.Equal(t, feat, result.BumpCause)
+	assert.True(t, result.Commits[0].Cancelled)
+	assert.True(t, result.Commits[1].Cancelled)
+	assert.False(t, result.Commits[2].Cancelled)
+}
+
+func TestFindNextVersion_RevertOfReleasedCommit(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "feature.go", "feat: x")
+	feat := headHash(t, repo)
+	tagHead(t, repo, "1.0.0")
+	fakeCommit(t, repo, fs, "feature_revert.go", fmt.Sprintf("revert: x\n\nThis reverts commit %s.", feat))
+	opts := newOptions(t)
+	opts.IncMapping = append(opts.IncMapping, model.Tuple[string, model.Bump]{First: "revert", Second: model.BumpPatch})
+	result, err := findNextVersion(repo, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.Tag)
+	assert.False(t, result.Commits[0].Cancelled)
+	assert.Equal(t, []string{feat}, result.Commits[0].Reverts)
+}
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/internal/worktree"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func FindSnapshot(repositoryPath string, format model.SnapshotFormat, opts Options) (*Result, error) {
+	opts.Log.Printf("Finding %s snapshot version in %s\n", format, repositoryPath)
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	return findSnapshot(repo, format, opts)
+}
+
+// findSnapshot computes the version of a build between releases from the
+// distance to the previous release and the evaluated commit. A dirty worktree
+// appends "dirty" to the build metadata, or to the commit in describe format.
+func findSnapshot(repo *git.Repository, format model.SnapshotFormat, opts Options) (*Result, error) {
+	result, _, _, err := analyze(repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Ref == "" && opts.Target == "" {
+		if result.Dirty, err = worktree.IsDirty(repo); err != nil {
+			return nil, err
+		}
+	}
+
+	commit := "g" + result.HeadCommit[:7]
+	previous := semver.SemVer{}
+	if result.PreviousVersion != nil {
+		previous = *result.PreviousVersion
+	}
+	version := previous
+	switch {
+	case result.Distance == 0 && !result.Dirty && result.PreviousVersion != nil:
+		opts.Log.Printf("Commit %s is released as %s\n", result.HeadCommit, result.PreviousTag)
+	case format == model.SnapshotDescribe:
+		version.PreRelease = append(version.PreRelease, strconv.Itoa(result.Distance)+"-"+commit)
+		if result.Dirty {
+			version.PreRelease = append(version.PreRelease, "dirty")
+		}
+	case format == model.SnapshotDev:
+		version = result.Version
+		if result.Bump == model.BumpNone {
+			version = previous.Core().IncPatch()
+		}
+		version.PreRelease = []string{"dev", strconv.Itoa(result.Distance)}
+		version.Build = []string{commit}
+		if result.Dirty {
+			version.Build = append(version.Build, "dirty")
+		}
+	default:
+		return nil, fmt.Errorf("invalid snapshot format '%s'", format)
+	}
+	result.Version = version
+	result.Tag = opts.TagFormat.Format(version.String())
+	return result, nil
+}
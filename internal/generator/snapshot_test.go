@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSnapshot(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "v1.3.2")
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	fakeCommit(t, repo, fs, "util.go", "fix: fix a bug")
+	fakeCommit(t, repo, fs, "docs.go", "docs: update readme")
+	head := headHash(t, repo)
+	opts := newOptions(t)
+	opts.TagFormat = model.TagFormat{Prefix: "v"}
+
+	result, err := findSnapshot(repo, model.SnapshotDev, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.4.0-dev.3+g"+head[:7], result.Tag)
+	assert.Equal(t, 3, result.Distance)
+	assert.False(t, result.Dirty)
+
+	result, err = findSnapshot(repo, model.SnapshotDescribe, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.2-3-g"+head[:7], result.Tag)
+}
+
+func TestFindSnapshot_NoBump(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.3.2")
+	fakeCommit(t, repo, fs, "docs.go", "docs: update readme")
+	head := headHash(t, repo)
+	result, err := findSnapshot(repo, model.SnapshotDev, newOptions(t))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.3-dev.1+g"+head[:7], result.Tag)
+}
+
+func TestFindSnapshot_Released(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	fakeCommit(t, repo, fs, "main.go", "feat: some new feature")
+	tagHead(t, repo, "1.3.2")
+
+	for _, format := range []model.SnapshotFormat{model.SnapshotDev, model.SnapshotDescribe} {
+		result, err := findSnapshot(repo, format, newOptions(t))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.2", result.Tag, format)
+	}
+}
+
+func TestFindSnapshot_Dirty(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.3.2")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	head := headHash(t, repo)
+	f, err := fs.Create("main.go")
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("changed"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	result, err := findSnapshot(repo, model.SnapshotDev, newOptions(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.3-dev.1+g"+head[:7]+".dirty", result.Tag)
+	assert.True(t, result.Dirty)
+
+	result, err = findSnapshot(repo, model.SnapshotDescribe, newOptions(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.2-1-g"+head[:7]+".dirty", result.Tag)
+}
+
+func TestFindSnapshot_UntrackedFileIsNotDirty(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.3.2")
+	fakeCommit(t, repo, fs, "main.go", "fix: fix a bug")
+	head := headHash(t, repo)
+	f, err := fs.Create("untracked.go")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	result, err := findSnapshot(repo, model.SnapshotDescribe, newOptions(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.2-1-g"+head[:7], result.Tag)
+	assert.False(t, result.Dirty)
+}
+
+func TestFindSnapshot_ComponentDistance(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := NewSimulatedRepository(t)
+	tagHead(t, repo, "1.3.2")
+	fakeCommit(t, repo, fs, "services/a/main.go", "fix: fix a bug")
+	fakeCommit(t, repo, fs, "services/b/main.go", "fix: fix another bug")
+	fakeCommit(t, repo, fs, "docs.md", "docs: update docs")
+	head := headHash(t, repo)
+	opts := newOptions(t)
+	opts.Paths = []string{"services/a"}
+
+	result, err := findSnapshot(repo, model.SnapshotDescribe, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Distance)
+	assert.Equal(t, "1.3.2-1-g"+head[:7], result.Tag)
+}
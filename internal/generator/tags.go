@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type versionTag struct {
+	Name    string
+	Version semver.SemVer
+	Hash    string
+}
+
+func findVersionTags(repo *git.Repository, opts Options) ([]versionTag, error) {
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []versionTag
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		commitId, err := repo.ResolveRevision(plumbing.Revision(ref.Name()))
+		if err != nil {
+			return nil
+		}
+
+		version, ok := opts.TagFormat.Extract(name)
+		if !ok {
+			if opts.IgnoreForeignTags {
+				opts.Log.Printf("Tag %s is not a version tag of this component, ignoring\n", name)
+				return nil
+			}
+			if opts.IgnoreInvalidTags {
+				opts.Log.Printf("Tag %s does not match tag format %s, ignoring\n", name, opts.TagFormat)
+				return nil
+			}
+			return fmt.Errorf("Tag %s does not match tag format %s", name, opts.TagFormat)
+		}
+		semVer, err := semver.Parse(version)
+		if err != nil {
+			if opts.IgnoreForeignTags {
+				opts.Log.Printf("Tag %s is not a version tag of this component, ignoring\n", name)
+				return nil
+			}
+			if opts.IgnoreInvalidTags {
+				opts.Log.Printf("Tag %s is not a valid semantic version, ignoring\n", name)
+				return nil
+			}
+			return fmt.Errorf("Tag %s is not a valid semantic version: %w", name, err)
+		}
+		tags = append(tags, versionTag{Name: name, Version: semVer, Hash: commitId.String()})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func isRelease(tag versionTag) bool {
+	return !tag.Version.IsPreRelease()
+}
+
+func isPreRelease(tag versionTag) bool {
+	return tag.Version.IsPreRelease()
+}
+
+func tagsByHash(tags []versionTag, accept func(versionTag) bool) map[plumbing.Hash][]versionTag {
+	byHash := map[plumbing.Hash][]versionTag{}
+	for _, tag := range tags {
+		if accept(tag) {
+			hash := plumbing.NewHash(tag.Hash)
+			byHash[hash] = append(byHash[hash], tag)
+		}
+	}
+	return byHash
+}
+
+// shallowBoundary returns the commits of a shallow clone whose parents were not
+// fetched, which is empty for complete repositories.
+func shallowBoundary(repo *git.Repository) (map[plumbing.Hash]bool, error) {
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return nil, err
+	}
+	boundary := map[plumbing.Hash]bool{}
+	for _, hash := range shallow {
+		boundary[hash] = true
+	}
+	return boundary, nil
+}
+
+// parentHashes returns the parents of the commit, except for parents of a
+// shallow boundary commit that were not fetched.
+func parentHashes(repo *git.Repository, c *object.Commit, boundary map[plumbing.Hash]bool) []plumbing.Hash {
+	if !boundary[c.Hash] {
+		return c.ParentHashes
+	}
+	var parents []plumbing.Hash
+	for _, parent := range c.ParentHashes {
+		if repo.Storer.HasEncodedObject(parent) == nil {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// walkUntilTagged visits every commit reachable from the given commit, but
+// does not continue past commits that carry one of the given tags or past the
+// boundary of a shallow clone.
+func walkUntilTagged(repo *git.Repository, from plumbing.Hash, tagged map[plumbing.Hash][]versionTag, visit func(c *object.Commit, tags []versionTag)) error {
+	boundary, err := shallowBoundary(repo)
+	if err != nil {
+		return err
+	}
+
+	visited := map[plumbing.Hash]bool{from: true}
+	queue := []plumbing.Hash{from}
+	for len(queue) > 0 {
+		c, err := repo.CommitObject(queue[0])
+		if err != nil {
+			return err
+		}
+		queue = queue[1:]
+
+		tags := tagged[c.Hash]
+		visit(c, tags)
+		if len(tags) > 0 {
+			continue
+		}
+		for _, parent := range parentHashes(repo, c, boundary) {
+			if !visited[parent] {
+				visited[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// findNearestTag returns the highest accepted tag among the tags that are
+// reachable from one of the heads and not an ancestor of another accepted tag.
+// If accepted tags exist but none is reachable, it returns a warning instead,
+// unless the repository is a shallow clone missing the tagged commits.
+func findNearestTag(repo *git.Repository, heads []plumbing.Hash, tags []versionTag, accept func(versionTag) bool, log logger.Logger) (*versionTag, string, error) {
+	tagged := tagsByHash(tags, accept)
+
+	var nearest *versionTag
+	for _, head := range heads {
+		err := walkUntilTagged(repo, head, tagged, func(c *object.Commit, tags []versionTag) {
+			for i, tag := range tags {
+				log.Printf("Found reachable tag %s on commit %s\n", tag.Name, c.Hash.String())
+				if nearest == nil || nearest.Version.LessThan(tag.Version) {
+					nearest = &tags[i]
+				}
+			}
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if nearest == nil && len(tagged) > 0 {
+		shallow, err := repo.Storer.Shallow()
+		if err != nil {
+			return nil, "", err
+		}
+		if len(shallow) > 0 {
+			return nil, "", fmt.Errorf("none of the %d version tags is reachable in this shallow clone, fetch the full history (e.g. git fetch --unshallow --tags)", len(tagged))
+		}
+		warning := fmt.Sprintf("none of the %d version tags is reachable, assuming no previous release", len(tagged))
+		log.Println("Warning: " + warning)
+		return nil, warning, nil
+	}
+	return nearest, "", nil
+}
@@ -1,26 +1,36 @@
 package generator
 
 import (
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/StevenCyb/autosemver/internal/logger"
 	"github.com/StevenCyb/autosemver/internal/model"
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
 
-var conventionalCommitToSemVer = []model.Tuple[string, string]{
-	{First: "breaking change", Second: "major"},
-	{First: "fix!", Second: "major"},
-	{First: "feat!", Second: "major"},
-	{First: "feat", Second: "minor"},
-	{First: "pref", Second: "patch"},
-	{First: "fix", Second: "patch"},
+var conventionalCommitToSemVer = []model.Tuple[string, model.Bump]{
+	{First: "feat", Second: model.BumpMinor},
+	{First: "perf", Second: model.BumpPatch},
+	{First: "fix", Second: model.BumpPatch},
+}
+
+func newOptions(t *testing.T) Options {
+	t.Helper()
+
+	return Options{
+		IncMapping: conventionalCommitToSemVer,
+		Log:        logger.Silent{},
+	}
 }
 
 func NewSimulatedRepository(t *testing.T) (*git.Repository, billy.Filesystem) {
@@ -45,14 +55,47 @@ func NewSimulatedRepository(t *testing.T) (*git.Repository, billy.Filesystem) {
 }
 
 func fakeCommit(t *testing.T, repo *git.Repository, fs billy.Filesystem, fileName, commitMessage string) {
+	fakeCommitContent(t, repo, fs, fileName, "content", commitMessage)
+}
+
+func fakeCommitContent(t *testing.T, repo *git.Repository, fs billy.Filesystem, fileName, content, commitMessage string) {
 	wt, err := repo.Worktree()
 	assert.NoError(t, err)
 
 	f, err := fs.Create(fileName)
 	assert.NoError(t, err)
-	_, err = f.Write([]byte("content"))
+	_, err = f.Write([]byte(content))
+	assert.NoError(t, err)
+
+	_, err = wt.Add(fileName)
+	assert.NoError(t, err)
+
+	_, err = wt.Commit(commitMessage, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test Bot",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+	})
+	assert.NoError(t, err)
+}
+
+// fakeMergeCommit commits the file on the current branch with the head of the
+// given branch as second parent.
+func fakeMergeCommit(t *testing.T, repo *git.Repository, fs billy.Filesystem, fileName, branchName, commitMessage string) {
+	t.Helper()
+
+	head, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	branch, err := repo.Reference(plumbing.NewBranchReferenceName(branchName), true)
+	assert.NoError(t, err)
+	wt, err := repo.Worktree()
 	assert.NoError(t, err)
 
+	f, err := fs.Create(fileName)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("content"))
+	assert.NoError(t, err)
 	_, err = wt.Add(fileName)
 	assert.NoError(t, err)
 
@@ -62,6 +105,123 @@ func fakeCommit(t *testing.T, repo *git.Repository, fs billy.Filesystem, fileNam
 			Email: "test@example.com",
 			When:  time.Now(),
 		},
+		Parents: []plumbing.Hash{head.Hash(), branch.Hash()},
 	})
 	assert.NoError(t, err)
 }
+
+func checkoutBranch(t *testing.T, repo *git.Repository, branchName string, create bool) {
+	t.Helper()
+
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	err = wt.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branchName),
+		Create: create,
+	})
+	assert.NoError(t, err)
+}
+
+func tagHead(t *testing.T, repo *git.Repository, tagName string) {
+	t.Helper()
+
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag(tagName, headRef.Hash(), nil)
+	assert.NoError(t, err)
+}
+
+func headHash(t *testing.T, repo *git.Repository) string {
+	t.Helper()
+
+	headRef, err := repo.Reference(plumbing.HEAD, true)
+	assert.NoError(t, err)
+	return headRef.Hash().String()
+}
+
+// writeNotes commits the notes by path, e.g. a commit hash or a fanout path
+// like "ab/cdef...", to NotesRef.
+func writeNotes(t *testing.T, repo *git.Repository, notes map[string]string) {
+	t.Helper()
+
+	root := map[string]any{}
+	for path, note := range notes {
+		dir := root
+		segments := strings.Split(path, "/")
+		for _, segment := range segments[:len(segments)-1] {
+			if _, ok := dir[segment]; !ok {
+				dir[segment] = map[string]any{}
+			}
+			dir = dir[segment].(map[string]any)
+		}
+		dir[segments[len(segments)-1]] = note
+	}
+
+	signature := object.Signature{Name: "Test Bot", Email: "test@example.com", When: time.Now()}
+	commit := &object.Commit{Author: signature, Committer: signature, Message: "Notes added by 'git notes add'", TreeHash: writeNotesTree(t, repo, root)}
+	obj := repo.Storer.NewEncodedObject()
+	assert.NoError(t, commit.Encode(obj))
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(NotesRef, hash)))
+}
+
+func writeNotesTree(t *testing.T, repo *git.Repository, dir map[string]any) plumbing.Hash {
+	t.Helper()
+
+	tree := &object.Tree{}
+	for name, entry := range dir {
+		if sub, ok := entry.(map[string]any); ok {
+			tree.Entries = append(tree.Entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: writeNotesTree(t, repo, sub)})
+			continue
+		}
+		blob := repo.Storer.NewEncodedObject()
+		blob.SetType(plumbing.BlobObject)
+		w, err := blob.Writer()
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(entry.(string)))
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+		hash, err := repo.Storer.SetEncodedObject(blob)
+		assert.NoError(t, err)
+		tree.Entries = append(tree.Entries, object.TreeEntry{Name: name, Mode: filemode.Regular, Hash: hash})
+	}
+	sort.Slice(tree.Entries, func(i, j int) bool { return tree.Entries[i].Name < tree.Entries[j].Name })
+	obj := repo.Storer.NewEncodedObject()
+	assert.NoError(t, tree.Encode(obj))
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	assert.NoError(t, err)
+	return hash
+}
+
+// newShallowClone commits the messages to a repository on disk, tagging the
+// commits at the given indexes, and clones it with the given depth.
+func newShallowClone(t *testing.T, depth int, messages []string, tags map[int]string) *git.Repository {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	for i, message := range messages {
+		hash, err := wt.Commit(message, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author: &object.Signature{
+				Name:  "Test Bot",
+				Email: "test@example.com",
+				When:  time.Now(),
+			},
+		})
+		assert.NoError(t, err)
+		if tag, ok := tags[i]; ok {
+			_, err = repo.CreateTag(tag, hash, nil)
+			assert.NoError(t, err)
+		}
+	}
+
+	clone, err := git.PlainClone(t.TempDir(), false, &git.CloneOptions{URL: dir, Depth: depth, Tags: git.AllTags})
+	assert.NoError(t, err)
+	return clone
+}
@@ -0,0 +1,254 @@
+package gomod
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var majorDirPattern = regexp.MustCompile(`^v([2-9]|[1-9][0-9]+)$`)
+
+// SetModulePath replaces the path of the module directive of a go.mod file.
+func SetModulePath(data []byte, modulePath string) ([]byte, bool) {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		fields, err := tokenize(line)
+		if err != nil || len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		if fields[1] == modulePath {
+			return data, false
+		}
+		lines[i] = "module " + modulePath
+		return []byte(strings.Join(lines, "\n")), true
+	}
+	return data, false
+}
+
+// RewriteImports replaces imports of oldPath and its packages by newPath.
+func RewriteImports(filename string, src []byte, oldPath, newPath string) ([]byte, bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, false, err
+	}
+
+	type replacement struct {
+		start, end int
+		value      string
+	}
+	var replacements []replacement
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+		// "example.com/x/v2/pkg" belongs to another major version of "example.com/x"
+		rest := strings.TrimPrefix(importPath, oldPath)
+		if segments := strings.SplitN(rest, "/", 3); len(segments) > 1 && majorDirPattern.MatchString(segments[1]) {
+			continue
+		}
+		start := int(spec.Path.Pos()) - 1
+		replacements = append(replacements, replacement{
+			start: start,
+			end:   start + len(spec.Path.Value),
+			value: strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)),
+		})
+	}
+	if len(replacements) == 0 {
+		return src, false, nil
+	}
+
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start > replacements[j].start
+	})
+	out := append([]byte{}, src...)
+	for _, r := range replacements {
+		out = append(out[:r.start], append([]byte(r.value), out[r.end:]...)...)
+	}
+	return out, true, nil
+}
+
+// SetRequirement replaces oldPath in the require and replace directives of a
+// go.mod file by newPath. Requirements get the version, replacements lose the
+// version of the replaced module since it belongs to the old path.
+func SetRequirement(data []byte, oldPath, newPath, version string) ([]byte, bool) {
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	block := ""
+	for i, line := range lines {
+		fields, err := tokenize(line)
+		if err != nil || len(fields) == 0 {
+			continue
+		}
+		if block != "" && len(fields) == 1 && fields[0] == ")" {
+			block = ""
+			continue
+		}
+		if block == "" && len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		prefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		verb, args := block, fields
+		if block == "" {
+			verb, args = fields[0], fields[1:]
+			prefix += verb + " "
+		}
+		if len(args) == 0 || args[0] != oldPath {
+			continue
+		}
+		var rewritten string
+		switch verb {
+		case "require":
+			rewritten = prefix + newPath + " " + version
+		case "replace":
+			arrow := strings.Index(line, "=>")
+			if arrow == -1 {
+				continue
+			}
+			rewritten = prefix + newPath + " " + line[arrow:]
+		default:
+			continue
+		}
+		if comment := strings.Index(line, "//"); comment != -1 && !strings.Contains(rewritten, "//") {
+			rewritten += " " + line[comment:]
+		}
+		lines[i] = rewritten
+		changed = true
+	}
+	return []byte(strings.Join(lines, "\n")), changed
+}
+
+// FixModulePath changes the path of the module in dir to newPath and rewrites
+// all imports of the module in its Go files. Nested modules and vendor
+// directories are skipped. It returns the changed files.
+func FixModulePath(dir, newPath string) ([]string, error) {
+	goModPath := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	file, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	oldPath := file.Module
+
+	var changed []string
+	if data, ok := SetModulePath(data, newPath); ok {
+		if err := os.WriteFile(goModPath, data, 0o644); err != nil {
+			return nil, err
+		}
+		changed = append(changed, goModPath)
+	}
+	rewritten, err := rewriteModuleImports(dir, oldPath, newPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(changed, rewritten...), nil
+}
+
+// FixDependentModules updates the other modules below root that require or
+// replace the module oldPath: their go.mod files require newPath at the
+// version instead, and their imports are rewritten. It returns the changed
+// files.
+func FixDependentModules(root, oldPath, newPath, version string) ([]string, error) {
+	var changed []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "go.mod" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		file, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if file.Module == oldPath || file.Module == newPath {
+			return nil
+		}
+		data, ok := SetRequirement(data, oldPath, newPath, version)
+		if !ok {
+			return nil
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		changed = append(changed, path)
+		rewritten, err := rewriteModuleImports(filepath.Dir(path), oldPath, newPath)
+		if err != nil {
+			return err
+		}
+		changed = append(changed, rewritten...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// rewriteModuleImports rewrites the imports of oldPath in the Go files of the
+// module in dir, skipping nested modules and vendor directories.
+func rewriteModuleImports(dir, oldPath, newPath string) ([]string, error) {
+	var changed []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		out, ok, err := RewriteImports(path, src, oldPath, newPath)
+		if err != nil || !ok {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		changed = append(changed, path)
+		return os.WriteFile(path, out, info.Mode().Perm())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
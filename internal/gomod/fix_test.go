@@ -0,0 +1,121 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteImports(t *testing.T) {
+	t.Parallel()
+
+	src := `package main
+
+import (
+	"fmt"
+	x "example.com/x"
+	"example.com/x/pkg/util"
+	"example.com/x/v3/pkg"
+	"example.com/xy"
+)
+`
+	out, ok, err := RewriteImports("main.go", []byte(src), "example.com/x", "example.com/x/v2")
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `package main
+
+import (
+	"fmt"
+	x "example.com/x/v2"
+	"example.com/x/v2/pkg/util"
+	"example.com/x/v3/pkg"
+	"example.com/xy"
+)
+`, string(out))
+}
+
+func TestFixModulePath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/x // the module\n\ngo 1.23\n",
+		"main.go":          "package main\n\nimport \"example.com/x/pkg\"\n",
+		"pkg/pkg.go":       "package pkg\n",
+		"nested/go.mod":    "module example.com/x/nested\n",
+		"nested/nested.go": "package nested\n\nimport \"example.com/x/pkg\"\n",
+		"vendor/a/a.go":    "package a\n\nimport \"example.com/x/pkg\"\n",
+		"README.md":        "example.com/x\n",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	changed, err := FixModulePath(dir, "example.com/x/v2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "go.mod"), filepath.Join(dir, "main.go")}, changed)
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "module example.com/x/v2\n\ngo 1.23\n", read("go.mod"))
+	assert.Equal(t, "package main\n\nimport \"example.com/x/v2/pkg\"\n", read("main.go"))
+	assert.Equal(t, files["nested/nested.go"], read("nested/nested.go"))
+	assert.Equal(t, files["vendor/a/a.go"], read("vendor/a/a.go"))
+}
+
+func TestSetRequirement(t *testing.T) {
+	t.Parallel()
+
+	data := "module example.com/y\n\nrequire example.com/x v1.2.0 // indirect\n\nrequire (\n\texample.com/xy v1.0.0\n\texample.com/x v1.2.0\n)\n\nreplace example.com/x v1.2.0 => ../x\n"
+	out, ok := SetRequirement([]byte(data), "example.com/x", "example.com/x/v2", "v2.0.0")
+
+	assert.True(t, ok)
+	assert.Equal(t, "module example.com/y\n\nrequire example.com/x/v2 v2.0.0 // indirect\n\nrequire (\n\texample.com/xy v1.0.0\n\texample.com/x/v2 v2.0.0\n)\n\nreplace example.com/x/v2 => ../x\n", string(out))
+
+	_, ok = SetRequirement([]byte(data), "example.com/z", "example.com/z/v2", "v2.0.0")
+	assert.False(t, ok)
+}
+
+func TestFixDependentModules(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":               "module example.com/x/v2\n",
+		"main.go":              "package main\n\nimport \"example.com/x/v2/pkg\"\n",
+		"nested/go.mod":        "module example.com/x/nested\n\nrequire example.com/x v1.2.0\n\nreplace example.com/x => ../\n",
+		"nested/nested.go":     "package nested\n\nimport \"example.com/x/pkg\"\n",
+		"nested/sub/sub.go":    "package sub\n\nimport \"example.com/x\"\n",
+		"unrelated/go.mod":     "module example.com/unrelated\n",
+		"unrelated/unrel.go":   "package unrelated\n\nimport \"example.com/x/pkg\"\n",
+		"nested/vendor/a/a.go": "package a\n\nimport \"example.com/x/pkg\"\n",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	changed, err := FixDependentModules(dir, "example.com/x", "example.com/x/v2", "v2.0.0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "nested", "go.mod"),
+		filepath.Join(dir, "nested", "nested.go"),
+		filepath.Join(dir, "nested", "sub", "sub.go"),
+	}, changed)
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "module example.com/x/nested\n\nrequire example.com/x/v2 v2.0.0\n\nreplace example.com/x/v2 => ../\n", read("nested/go.mod"))
+	assert.Equal(t, "package nested\n\nimport \"example.com/x/v2/pkg\"\n", read("nested/nested.go"))
+	assert.Equal(t, "package sub\n\nimport \"example.com/x/v2\"\n", read("nested/sub/sub.go"))
+	assert.Equal(t, files["unrelated/unrel.go"], read("unrelated/unrel.go"))
+	assert.Equal(t, files["nested/vendor/a/a.go"], read("nested/vendor/a/a.go"))
+}
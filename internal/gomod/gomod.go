@@ -0,0 +1,181 @@
+// Package gomod reads the parts of go.mod and go.work files that are needed to
+// relate the modules of a repository. It is not a complete implementation of
+// the file formats.
+package gomod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Require struct {
+	Path    string
+	Version string
+}
+
+type Replace struct {
+	Old        string
+	OldVersion string
+	New        string
+	NewVersion string
+}
+
+// IsLocal reports whether the module is replaced by a directory.
+func (r Replace) IsLocal() bool {
+	return strings.HasPrefix(r.New, "./") || strings.HasPrefix(r.New, "../") || r.New == "." || r.New == ".."
+}
+
+type File struct {
+	Module   string
+	Requires []Require
+	Replaces []Replace
+}
+
+type Work struct {
+	Uses []string
+}
+
+type directive struct {
+	verb string
+	args []string
+	line int
+}
+
+// Parse parses a go.mod file.
+func Parse(data []byte) (*File, error) {
+	directives, err := parseDirectives(data)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{}
+	for _, d := range directives {
+		switch d.verb {
+		case "module":
+			if len(d.args) != 1 {
+				return nil, fmt.Errorf("line %d: usage: module path", d.line)
+			}
+			file.Module = d.args[0]
+		case "require":
+			if len(d.args) != 2 {
+				return nil, fmt.Errorf("line %d: usage: require module/path v1.2.3", d.line)
+			}
+			file.Requires = append(file.Requires, Require{Path: d.args[0], Version: d.args[1]})
+		case "replace":
+			replace, err := parseReplace(d)
+			if err != nil {
+				return nil, err
+			}
+			file.Replaces = append(file.Replaces, replace)
+		}
+	}
+	if file.Module == "" {
+		return nil, fmt.Errorf("no module directive found")
+	}
+	return file, nil
+}
+
+// ParseWork parses a go.work file.
+func ParseWork(data []byte) (*Work, error) {
+	directives, err := parseDirectives(data)
+	if err != nil {
+		return nil, err
+	}
+
+	work := &Work{}
+	for _, d := range directives {
+		if d.verb == "use" {
+			if len(d.args) != 1 {
+				return nil, fmt.Errorf("line %d: usage: use local/dir", d.line)
+			}
+			work.Uses = append(work.Uses, d.args[0])
+		}
+	}
+	return work, nil
+}
+
+func parseReplace(d directive) (Replace, error) {
+	arrow := -1
+	for i, arg := range d.args {
+		if arg == "=>" {
+			arrow = i
+		}
+	}
+	if arrow < 1 || arrow > 2 || len(d.args)-arrow-1 < 1 || len(d.args)-arrow-1 > 2 {
+		return Replace{}, fmt.Errorf("line %d: usage: replace module/path [v1.2.3] => other/module v1.4 or local/dir", d.line)
+	}
+	replace := Replace{Old: d.args[0], New: d.args[arrow+1]}
+	if arrow == 2 {
+		replace.OldVersion = d.args[1]
+	}
+	if len(d.args) == arrow+3 {
+		replace.NewVersion = d.args[arrow+2]
+	}
+	return replace, nil
+}
+
+// parseDirectives splits the file into directives, expanding blocks like
+// "require ( ... )" into one directive per line.
+func parseDirectives(data []byte) ([]directive, error) {
+	var directives []directive
+	block := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		fields, err := tokenize(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		if block != "" {
+			if len(fields) == 1 && fields[0] == ")" {
+				block = ""
+				continue
+			}
+			directives = append(directives, directive{verb: block, args: fields, line: i + 1})
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		directives = append(directives, directive{verb: fields[0], args: fields[1:], line: i + 1})
+	}
+	if block != "" {
+		return nil, fmt.Errorf("unterminated %s block", block)
+	}
+	return directives, nil
+}
+
+// tokenize splits a line into fields, unquoting quoted strings and dropping
+// comments.
+func tokenize(line string) ([]string, error) {
+	var fields []string
+	for {
+		line = strings.TrimLeft(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "//") {
+			return fields, nil
+		}
+		switch line[0] {
+		case '"', '`':
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, err
+			}
+			field, _ := strconv.Unquote(quoted)
+			fields = append(fields, field)
+			line = line[len(quoted):]
+		default:
+			end := strings.IndexAny(line, " \t\r")
+			if end == -1 {
+				end = len(line)
+			}
+			if comment := strings.Index(line[:end], "//"); comment != -1 {
+				end = comment
+			}
+			fields = append(fields, line[:end])
+			line = line[end:]
+		}
+	}
+}
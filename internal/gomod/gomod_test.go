@@ -0,0 +1,74 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	file, err := Parse([]byte(`// comment
+module example.com/svc // trailing comment
+
+go 1.23
+
+require example.com/libs v1.2.0
+require (
+	github.com/stretchr/testify v1.10.0
+	"example.com/quoted" v0.1.0 // indirect
+)
+
+replace example.com/libs => ../libs
+replace (
+	example.com/old v1.0.0 => example.com/new v1.1.0
+)
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/svc", file.Module)
+	assert.Equal(t, []Require{
+		{Path: "example.com/libs", Version: "v1.2.0"},
+		{Path: "github.com/stretchr/testify", Version: "v1.10.0"},
+		{Path: "example.com/quoted", Version: "v0.1.0"},
+	}, file.Requires)
+	assert.Equal(t, []Replace{
+		{Old: "example.com/libs", New: "../libs"},
+		{Old: "example.com/old", OldVersion: "v1.0.0", New: "example.com/new", NewVersion: "v1.1.0"},
+	}, file.Replaces)
+	assert.True(t, file.Replaces[0].IsLocal())
+	assert.False(t, file.Replaces[1].IsLocal())
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{
+		"go 1.23\n",
+		"module a b\n",
+		"module a\nrequire (\n\tb v1.0.0\n",
+		"module a\nrequire b\n",
+		"module a\nreplace b =>\n",
+		"module \"a\n",
+	} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestParseWork(t *testing.T) {
+	t.Parallel()
+
+	work, err := ParseWork([]byte(`go 1.23
+
+use ./svc
+use (
+	./libs
+	./tools // tooling
+)
+`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"./svc", "./libs", "./tools"}, work.Uses)
+}
@@ -0,0 +1,51 @@
+package gomod
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	majorSuffixPattern = regexp.MustCompile(`^(.+)/v([0-9]+)$`)
+	gopkgSuffixPattern = regexp.MustCompile(`^(gopkg\.in/.+)\.v([0-9]+)(-unstable)?$`)
+)
+
+// SplitPathMajor splits a module path into the path without the major version
+// suffix and the major version, e.g. "example.com/x/v2" into "example.com/x"
+// and 2. The major version is 0 for paths without a suffix.
+func SplitPathMajor(path string) (string, uint64) {
+	if match := gopkgSuffixPattern.FindStringSubmatch(path); match != nil {
+		major, _ := strconv.ParseUint(match[2], 10, 64)
+		return match[1], major
+	}
+	if match := majorSuffixPattern.FindStringSubmatch(path); match != nil {
+		if major, err := strconv.ParseUint(match[2], 10, 64); err == nil && major >= 2 && !strings.HasPrefix(match[2], "0") {
+			return match[1], major
+		}
+	}
+	return path, 0
+}
+
+// PathForMajor returns the module path for the major version, e.g.
+// "example.com/x/v3" for "example.com/x/v2" and 3, and "example.com/x" for
+// major versions 0 and 1.
+func PathForMajor(path string, major uint64) string {
+	prefix, _ := SplitPathMajor(path)
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		return prefix + ".v" + strconv.FormatUint(major, 10)
+	}
+	if major < 2 {
+		return prefix
+	}
+	return prefix + "/v" + strconv.FormatUint(major, 10)
+}
+
+// MatchesMajor reports whether the module path is valid for the major version.
+func MatchesMajor(path string, major uint64) bool {
+	_, pathMajor := SplitPathMajor(path)
+	if strings.HasPrefix(path, "gopkg.in/") {
+		return pathMajor == major
+	}
+	return pathMajor == major || pathMajor == 0 && major < 2
+}
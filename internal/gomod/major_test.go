@@ -0,0 +1,50 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPathMajor(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path   string
+		prefix string
+		major  uint64
+	}{
+		{"example.com/x", "example.com/x", 0},
+		{"example.com/x/v2", "example.com/x", 2},
+		{"example.com/x/v12", "example.com/x", 12},
+		{"example.com/x/v1", "example.com/x/v1", 0},
+		{"example.com/x/v02", "example.com/x/v02", 0},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", 3},
+	} {
+		prefix, major := SplitPathMajor(tc.path)
+
+		assert.Equal(t, tc.prefix, prefix, tc.path)
+		assert.Equal(t, tc.major, major, tc.path)
+	}
+}
+
+func TestPathForMajor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "example.com/x/v2", PathForMajor("example.com/x", 2))
+	assert.Equal(t, "example.com/x/v3", PathForMajor("example.com/x/v2", 3))
+	assert.Equal(t, "example.com/x", PathForMajor("example.com/x/v2", 1))
+	assert.Equal(t, "gopkg.in/yaml.v4", PathForMajor("gopkg.in/yaml.v3", 4))
+}
+
+func TestMatchesMajor(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MatchesMajor("example.com/x", 0))
+	assert.True(t, MatchesMajor("example.com/x", 1))
+	assert.False(t, MatchesMajor("example.com/x", 2))
+	assert.True(t, MatchesMajor("example.com/x/v2", 2))
+	assert.False(t, MatchesMajor("example.com/x/v2", 3))
+	assert.True(t, MatchesMajor("gopkg.in/yaml.v3", 3))
+	assert.False(t, MatchesMajor("gopkg.in/yaml.v3", 4))
+}
@@ -0,0 +1,234 @@
+// Package hooks installs git hooks that run autosemver to reject commit
+// messages not following the commit convention.
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// Marker identifies hooks installed by autosemver, other hooks are never
+// overwritten or removed.
+const Marker = "# installed by autosemver"
+
+const (
+	CommitMsg = "commit-msg"
+	PrePush   = "pre-push"
+)
+
+var Names = []string{CommitMsg, PrePush}
+
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+-]+$`)
+
+type Options struct {
+	// Executable is the command used to run autosemver (default: autosemver).
+	Executable string
+	Log        logger.Logger
+}
+
+// Dir returns the hooks directory of the repository, which is core.hooksPath
+// if set in the repository, global or system git config, or the hooks
+// directory in the git directory.
+func Dir(repositoryPath string) (string, error) {
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return "", err
+	}
+	hooksPath, err := configuredHooksPath(repo)
+	if err != nil {
+		return "", err
+	}
+	if hooksPath != "" {
+		if rest, ok := strings.CutPrefix(hooksPath, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(home, rest), nil
+		}
+		if filepath.IsAbs(hooksPath) {
+			return hooksPath, nil
+		}
+		return filepath.Join(repositoryPath, hooksPath), nil
+	}
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("repository %s has no git directory", repositoryPath)
+	}
+	return filepath.Join(storage.Filesystem().Root(), "hooks"), nil
+}
+
+// configuredHooksPath returns core.hooksPath of the repository config, or of
+// the global or system config. Repository.ConfigScoped does not merge raw
+// options like this one, so each scope is read on its own.
+func configuredHooksPath(repo *git.Repository) (string, error) {
+	local, err := repo.Config()
+	if err != nil {
+		return "", err
+	}
+	if hooksPath := local.Raw.Section("core").Option("hooksPath"); hooksPath != "" {
+		return hooksPath, nil
+	}
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		cfg, err := config.LoadConfig(scope)
+		if err != nil {
+			return "", err
+		}
+		if hooksPath := cfg.Raw.Section("core").Option("hooksPath"); hooksPath != "" {
+			return hooksPath, nil
+		}
+	}
+	return "", nil
+}
+
+// Script returns the content of the hook.
+func Script(name, executable string) (string, error) {
+	switch name {
+	case CommitMsg:
+		return fmt.Sprintf(`#!/bin/sh
+%s
+# Rejects commit messages that are not valid conventional commits.
+exec %s lint --message-file="$1"
+`, Marker, shellQuote(executable)), nil
+	case PrePush:
+		return fmt.Sprintf(`#!/bin/sh
+%s
+# Rejects pushes of commits that are not valid conventional commits.
+zero=0000000000000000000000000000000000000000
+while read -r local_ref local_sha remote_ref remote_sha; do
+	if [ "$local_sha" = "$zero" ]; then
+		continue
+	fi
+	if [ "$remote_sha" = "$zero" ]; then
+		%s lint --to="$local_sha" || exit 1
+	else
+		%s lint --from="$remote_sha" --to="$local_sha" || exit 1
+	fi
+done
+`, Marker, shellQuote(executable), shellQuote(executable)), nil
+	}
+	return "", fmt.Errorf("unknown hook '%s', expected one of %s", name, strings.Join(Names, ", "))
+}
+
+// Install writes the hooks into the hooks directory of the repository. Hooks
+// installed by autosemver are updated, other existing hooks are left
+// untouched and result in an error.
+func Install(repositoryPath string, names []string, opts Options) ([]string, error) {
+	if opts.Executable == "" {
+		opts.Executable = "autosemver"
+	}
+	dir, err := Dir(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := make(map[string]string, len(names))
+	for _, name := range names {
+		script, err := Script(name, opts.Executable)
+		if err != nil {
+			return nil, err
+		}
+		// check all hooks before writing any of them
+		if err := checkOwned(filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+		scripts[name] = script
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var installed []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		opts.Log.Printf("Installing %s hook to %s\n", name, path)
+		if err := os.WriteFile(path, []byte(scripts[name]), 0o755); err != nil {
+			return installed, err
+		}
+		// WriteFile keeps the mode of existing files
+		if err := os.Chmod(path, 0o755); err != nil {
+			return installed, err
+		}
+		installed = append(installed, path)
+	}
+	return installed, nil
+}
+
+// Uninstall removes the hooks installed by autosemver. Missing hooks are
+// skipped. Without names all hooks are removed and hooks not installed by
+// autosemver are skipped, if named they result in an error.
+func Uninstall(repositoryPath string, names []string, opts Options) ([]string, error) {
+	dir, err := Dir(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	explicit := len(names) > 0
+	if !explicit {
+		names = Names
+	}
+	var owned []string
+	for _, name := range names {
+		if _, err := Script(name, ""); err != nil {
+			return nil, err
+		}
+		if err := checkOwned(filepath.Join(dir, name)); err != nil {
+			if explicit {
+				return nil, err
+			}
+			opts.Log.Printf("Skipping %s hook: %s\n", name, err)
+			continue
+		}
+		owned = append(owned, name)
+	}
+
+	var removed []string
+	for _, name := range owned {
+		path := filepath.Join(dir, name)
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			opts.Log.Printf("No %s hook installed at %s\n", name, path)
+			continue
+		}
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// shellQuote quotes the argument for the shell unless it only consists of
+// characters without special meaning.
+func shellQuote(arg string) string {
+	if arg != "" && shellSafePattern.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// checkOwned returns an error if a hook exists at path that was not installed
+// by autosemver.
+func checkOwned(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(data), Marker) {
+		return fmt.Errorf("hook %s was not installed by autosemver, refusing to change it", path)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepository(t *testing.T) (string, *git.Repository) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	return dir, repo
+}
+
+// isolateHome makes the global git config of the test an empty temporary
+// directory. Tests using it cannot run in parallel.
+func isolateHome(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	return home
+}
+
+func TestDir(t *testing.T) {
+	isolateHome(t)
+
+	dir, _ := newRepository(t)
+	hooksDir, err := Dir(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".git", "hooks"), hooksDir)
+}
+
+func TestDir_HooksPath(t *testing.T) {
+	t.Parallel()
+
+	dir, repo := newRepository(t)
+	cfg, err := repo.Config()
+	assert.NoError(t, err)
+	cfg.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	assert.NoError(t, repo.SetConfig(cfg))
+	hooksDir, err := Dir(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".githooks"), hooksDir)
+}
+
+func TestDir_GlobalHooksPath(t *testing.T) {
+	home := isolateHome(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(home, ".gitconfig"), []byte("[core]\n\thooksPath = ~/.githooks\n"), 0o644))
+
+	dir, _ := newRepository(t)
+	hooksDir, err := Dir(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".githooks"), hooksDir)
+}
+
+func TestInstallAndUninstall(t *testing.T) {
+	isolateHome(t)
+
+	dir, _ := newRepository(t)
+	opts := Options{Log: logger.Silent{}}
+	installed, err := Install(dir, Names, opts)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, ".git", "hooks", CommitMsg), filepath.Join(dir, ".git", "hooks", PrePush)}, installed)
+	info, err := os.Stat(installed[0])
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	data, err := os.ReadFile(installed[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), Marker)
+	assert.Contains(t, string(data), `exec autosemver lint --message-file="$1"`)
+
+	// reinstalling updates the own hooks
+	_, err = Install(dir, []string{CommitMsg}, Options{Executable: "/usr/local/bin/autosemver", Log: logger.Silent{}})
+	assert.NoError(t, err)
+	data, err = os.ReadFile(installed[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `exec /usr/local/bin/autosemver lint`)
+
+	removed, err := Uninstall(dir, Names, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, installed, removed)
+	_, err = os.Stat(installed[0])
+	assert.True(t, os.IsNotExist(err))
+
+	removed, err = Uninstall(dir, Names, opts)
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func TestInstall_ForeignHook(t *testing.T) {
+	isolateHome(t)
+
+	dir, _ := newRepository(t)
+	foreign := filepath.Join(dir, ".git", "hooks", PrePush)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(foreign), 0o755))
+	assert.NoError(t, os.WriteFile(foreign, []byte("#!/bin/sh\nmake test\n"), 0o755))
+	opts := Options{Log: logger.Silent{}}
+
+	_, err := Install(dir, Names, opts)
+	assert.ErrorContains(t, err, "was not installed by autosemver, refusing to change it")
+	_, err = os.Stat(filepath.Join(dir, ".git", "hooks", CommitMsg))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = Uninstall(dir, []string{PrePush}, opts)
+	assert.Error(t, err)
+	data, err := os.ReadFile(foreign)
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nmake test\n", string(data))
+}
+
+func TestUninstall_SkipsForeignHook(t *testing.T) {
+	isolateHome(t)
+
+	dir, _ := newRepository(t)
+	opts := Options{Log: logger.Silent{}}
+	installed, err := Install(dir, Names, opts)
+	assert.NoError(t, err)
+	foreign := filepath.Join(dir, ".git", "hooks", PrePush)
+	assert.NoError(t, os.WriteFile(foreign, []byte("#!/bin/sh\nmake test\n"), 0o755))
+
+	removed, err := Uninstall(dir, nil, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{installed[0]}, removed)
+	_, err = os.Stat(installed[0])
+	assert.True(t, os.IsNotExist(err))
+	data, err := os.ReadFile(foreign)
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nmake test\n", string(data))
+}
+
+func TestScript_QuotesExecutable(t *testing.T) {
+	t.Parallel()
+
+	script, err := Script(CommitMsg, "/opt/my tools/autosemver")
+	assert.NoError(t, err)
+	assert.Contains(t, script, `exec '/opt/my tools/autosemver' lint --message-file="$1"`)
+
+	script, err = Script(PrePush, "it's/autosemver")
+	assert.NoError(t, err)
+	assert.Contains(t, script, `'it'\''s/autosemver' lint --to="$local_sha"`)
+}
+
+func TestScript_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := Script("pre-commit", "autosemver")
+	assert.ErrorContains(t, err, "unknown hook 'pre-commit'")
+}
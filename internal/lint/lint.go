@@ -0,0 +1,117 @@
+// Package lint checks commit messages against the Conventional Commits
+// specification and the configured commit types.
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+)
+
+// DefaultTypes are commit types that are allowed in addition to the types of
+// the mapping, even if they do not bump the version.
+var DefaultTypes = []string{"build", "chore", "ci", "docs", "refactor", "revert", "style", "test"}
+
+// scissors is the line below which git ignores the commit message.
+const scissors = "# ------------------------ >8 ------------------------"
+
+// ignoredPrefixes mark messages generated by git, which are not linted.
+var ignoredPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+type Rules struct {
+	// Types are the allowed commit types, compared case-insensitively. All
+	// types are allowed if empty.
+	Types []string
+}
+
+// CleanMessage removes comment lines and everything below the scissors line
+// like git does for edited commit messages.
+func CleanMessage(message string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n") {
+		if line == scissors {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Ignored reports whether the message is generated by git, e.g. for merges.
+func Ignored(message string) bool {
+	for _, prefix := range ignoredPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Message returns the problems of the commit message, or nil if it is valid.
+func Message(message string, rules Rules) []string {
+	if Ignored(message) {
+		return nil
+	}
+	commit, err := conventional.Parse(message)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var problems []string
+	if len(rules.Types) > 0 && !containsFold(rules.Types, commit.Type) {
+		problem := fmt.Sprintf("type '%s' is not one of %s", commit.Type, strings.Join(rules.Types, ", "))
+		if suggestion, ok := suggest(commit.Type, rules.Types); ok {
+			problem += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
+
+// suggest returns the allowed type closest to the given type, if it is close
+// enough to be a typo or an abbreviation.
+func suggest(commitType string, types []string) (string, bool) {
+	best, bestDistance := "", 3
+	for _, t := range types {
+		lowerType, lowerCommitType := strings.ToLower(t), strings.ToLower(commitType)
+		if strings.HasPrefix(lowerCommitType, lowerType) || strings.HasPrefix(lowerType, lowerCommitType) {
+			return t, true
+		}
+		if distance := levenshtein(lowerCommitType, lowerType); distance < bestDistance {
+			best, bestDistance = t, distance
+		}
+	}
+	return best, best != ""
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
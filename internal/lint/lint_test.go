@@ -0,0 +1,45 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	rules := Rules{Types: []string{"feat", "fix", "chore"}}
+	for _, tc := range []struct {
+		message  string
+		problems []string
+	}{
+		{"feat(api): add endpoint", nil},
+		{"FIX: fix bug", nil},
+		{"Merge branch 'main' into feature", nil},
+		{"Revert \"feat: add endpoint\"\n\nThis reverts commit abc.", nil},
+		{"fixup! feat: add endpoint", nil},
+		{"feat(api) missing colon", []string{conventional.ErrMissingSeparator.Error()}},
+		{"feat: add\nbody", []string{conventional.ErrMissingBlankLine.Error()}},
+		{"feature: add endpoint", []string{"type 'feature' is not one of feat, fix, chore (did you mean 'feat'?)"}},
+		{"fxi: fix bug", []string{"type 'fxi' is not one of feat, fix, chore (did you mean 'fix'?)"}},
+		{"docs: update readme", []string{"type 'docs' is not one of feat, fix, chore"}},
+	} {
+		assert.Equal(t, tc.problems, Message(tc.message, rules), tc.message)
+	}
+}
+
+func TestMessage_AnyType(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Message("whatever: message", Rules{}))
+}
+
+func TestCleanMessage(t *testing.T) {
+	t.Parallel()
+
+	message := "feat: add endpoint\n\nbody\n# Please enter the commit message\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+
+	assert.Equal(t, "feat: add endpoint\n\nbody", CleanMessage(message))
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// BranchRule restricts the versions of branches matching Pattern to Range.
+// An empty Range is derived from the last segment of the branch name, so
+// "release/1.4" and "release/1.4.x" both result in 1.4.x.
+type BranchRule struct {
+	Pattern string
+	Range   string
+}
+
+func ParseBranchRule(s string) (BranchRule, error) {
+	split := strings.SplitN(s, ":", 2)
+	rule := BranchRule{Pattern: split[0]}
+	if len(split) == 2 {
+		rule.Range = split[1]
+	}
+	if err := rule.Validate(); err != nil {
+		return BranchRule{}, err
+	}
+	return rule, nil
+}
+
+func (r BranchRule) Validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("branch rule pattern must not be empty")
+	}
+	if _, err := path.Match(r.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid branch rule pattern '%s': %w", r.Pattern, err)
+	}
+	if r.Range != "" {
+		if _, err := ParseVersionRange(r.Range); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r BranchRule) Resolve(branch string) (*VersionRange, error) {
+	if ok, _ := path.Match(r.Pattern, branch); !ok {
+		return nil, nil
+	}
+
+	rangeString := r.Range
+	if rangeString == "" {
+		rangeString = branch[strings.LastIndex(branch, "/")+1:]
+	}
+	versionRange, err := ParseVersionRange(rangeString)
+	if err != nil {
+		return nil, fmt.Errorf("branch %s matches rule %s: %w", branch, r.Pattern, err)
+	}
+	return &versionRange, nil
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/StevenCyb/autosemver/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersionRange(t *testing.T) {
+	t.Parallel()
+
+	for s, expected := range map[string]string{
+		"1":      "1.x",
+		"1.x":    "1.x",
+		"v2.x":   "2.x",
+		"1.4":    "1.4.x",
+		"1.4.x":  "1.4.x",
+		"v1.4.x": "1.4.x",
+	} {
+		versionRange, err := ParseVersionRange(s)
+
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, versionRange.String(), s)
+	}
+}
+
+func TestParseVersionRange_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "x", "1.4.2", "main", "1.a.x"} {
+		_, err := ParseVersionRange(s)
+
+		assert.Error(t, err, s)
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	t.Parallel()
+
+	minorRange, err := ParseVersionRange("1.4.x")
+	assert.NoError(t, err)
+	majorRange, err := ParseVersionRange("1.x")
+	assert.NoError(t, err)
+
+	assert.True(t, minorRange.Contains(semver.MustParse("1.4.7")))
+	assert.False(t, minorRange.Contains(semver.MustParse("1.5.0")))
+	assert.True(t, majorRange.Contains(semver.MustParse("1.5.0")))
+	assert.False(t, majorRange.Contains(semver.MustParse("2.0.0")))
+}
+
+func TestParseBranchRule(t *testing.T) {
+	t.Parallel()
+
+	rule, err := ParseBranchRule("release/*:1.4.x")
+	assert.NoError(t, err)
+	assert.Equal(t, BranchRule{Pattern: "release/*", Range: "1.4.x"}, rule)
+
+	rule, err = ParseBranchRule("maintenance/*")
+	assert.NoError(t, err)
+	assert.Equal(t, BranchRule{Pattern: "maintenance/*"}, rule)
+
+	_, err = ParseBranchRule("release/*:latest")
+	assert.Error(t, err)
+
+	_, err = ParseBranchRule("release/[")
+	assert.Error(t, err)
+}
+
+func TestBranchRule_Resolve(t *testing.T) {
+	t.Parallel()
+
+	rule := BranchRule{Pattern: "release/*"}
+
+	versionRange, err := rule.Resolve("release/v1.4.x")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.x", versionRange.String())
+
+	versionRange, err = rule.Resolve("main")
+	assert.NoError(t, err)
+	assert.Nil(t, versionRange)
+
+	_, err = rule.Resolve("release/next")
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package model
+
+import "fmt"
+
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func ParseBump(s string) (Bump, error) {
+	switch s {
+	case "none":
+		return BumpNone, nil
+	case "patch":
+		return BumpPatch, nil
+	case "minor":
+		return BumpMinor, nil
+	case "major":
+		return BumpMajor, nil
+	}
+	return BumpNone, fmt.Errorf("invalid bump '%s', expected one of {major, minor, patch, none}", s)
+}
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/pkg/semver"
+)
+
+// ValidateChannel checks that the pre-release channel is a single non-numeric
+// pre-release identifier, so that "<channel>.N" can be appended to versions.
+func ValidateChannel(channel string) error {
+	if channel == "" || strings.Contains(channel, ".") || isNumeric(channel) || !semver.IsValid("0.0.0-"+channel) {
+		return fmt.Errorf("invalid pre-release channel '%s', must be a non-numeric identifier of ASCII alphanumerics and hyphens", channel)
+	}
+	return nil
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChannel(t *testing.T) {
+	t.Parallel()
+
+	for _, channel := range []string{"rc", "beta", "pre-release", "alpha2"} {
+		assert.NoError(t, ValidateChannel(channel), channel)
+	}
+	for _, channel := range []string{"", "rc.1", "1", "r_c", "bêta"} {
+		assert.ErrorContains(t, ValidateChannel(channel), "invalid pre-release channel", channel)
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+import "fmt"
+
+// CheckLevel controls whether a failed check is an error, a warning or not
+// checked at all.
+type CheckLevel string
+
+const (
+	CheckError CheckLevel = "error"
+	CheckWarn  CheckLevel = "warn"
+	CheckOff   CheckLevel = "off"
+)
+
+func ParseCheckLevel(s string) (CheckLevel, error) {
+	switch level := CheckLevel(s); level {
+	case CheckError, CheckWarn, CheckOff:
+		return level, nil
+	}
+	return "", fmt.Errorf("invalid check level '%s', expected one of {error, warn, off}", s)
+}
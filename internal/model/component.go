@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+var componentNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// Component is an independently versioned part of a monorepo. Only commits
+// changing a file matching one of Paths and none of Exclude count towards its
+// version, and its releases are tagged in its own TagFormat, e.g.
+// "svc-a/v{version}".
+// Components get at least a patch release if one of DependsOn is released.
+type Component struct {
+	Name      string
+	Paths     []string
+	Exclude   []string
+	TagFormat TagFormat
+	DependsOn []string
+}
+
+// DefaultComponentTagFormat returns the tag format "<name>/v{version}".
+func DefaultComponentTagFormat(name string) TagFormat {
+	return TagFormat{Prefix: name + "/v"}
+}
+
+func (c Component) Validate() error {
+	if !componentNamePattern.MatchString(c.Name) {
+		return fmt.Errorf("invalid component name '%s', must consist of ASCII alphanumerics, '.', '_', '/' and '-'", c.Name)
+	}
+	if len(c.Paths) == 0 {
+		return fmt.Errorf("component %s must have at least one path", c.Name)
+	}
+	for _, p := range append(append([]string{}, c.Paths...), c.Exclude...) {
+		if p == "" {
+			return fmt.Errorf("component %s has an empty path", c.Name)
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid path '%s' of component %s: %w", p, c.Name, err)
+		}
+	}
+	for _, dependency := range c.DependsOn {
+		if dependency == c.Name {
+			return fmt.Errorf("component %s must not depend on itself", c.Name)
+		}
+	}
+	return nil
+}
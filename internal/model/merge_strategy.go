@@ -0,0 +1,27 @@
+package model
+
+import "fmt"
+
+// MergeStrategy controls how merge commits are evaluated.
+type MergeStrategy string
+
+const (
+	// MergeMessage evaluates the message of a merge commit like any other.
+	MergeMessage MergeStrategy = "message"
+	// MergeSkip ignores merge commits.
+	MergeSkip MergeStrategy = "skip"
+	// MergeTitle evaluates the pull request title in the merge commit body.
+	MergeTitle MergeStrategy = "title"
+	// MergeBranch evaluates the merged branch name, e.g. feat/foo as "feat: foo".
+	MergeBranch MergeStrategy = "branch"
+	// MergeCommits evaluates every commit listed in the merge commit body.
+	MergeCommits MergeStrategy = "commits"
+)
+
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	switch strategy := MergeStrategy(s); strategy {
+	case MergeMessage, MergeSkip, MergeTitle, MergeBranch, MergeCommits:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("invalid merge strategy '%s', expected one of {message, skip, title, branch, commits}", s)
+}
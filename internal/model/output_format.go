@@ -0,0 +1,21 @@
+package model
+
+import "fmt"
+
+// OutputFormat is the format versions are printed in.
+type OutputFormat string
+
+const (
+	OutputText   OutputFormat = "text"
+	OutputJSON   OutputFormat = "json"
+	OutputEnv    OutputFormat = "env"
+	OutputDotenv OutputFormat = "dotenv"
+)
+
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch format := OutputFormat(s); format {
+	case OutputText, OutputJSON, OutputEnv, OutputDotenv:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid output format '%s', expected one of {text, json, env, dotenv}", s)
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"text", "json", "env", "dotenv"} {
+		format, err := ParseOutputFormat(s)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OutputFormat(s), format)
+	}
+	_, err := ParseOutputFormat("yaml")
+	assert.Error(t, err)
+}
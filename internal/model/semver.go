@@ -1,8 +0,0 @@
-package model
-
-type SemVer struct {
-	Major uint
-	Minor uint
-	Patch uint
-	RC    *uint
-}
@@ -0,0 +1,23 @@
+package model
+
+import "fmt"
+
+// SnapshotFormat is the format of versions of builds between releases.
+type SnapshotFormat string
+
+const (
+	// SnapshotDev is the next version with the distance to the previous
+	// release and the commit, e.g. 1.4.0-dev.17+g3fa2b1c.
+	SnapshotDev SnapshotFormat = "dev"
+	// SnapshotDescribe is the previous version with the distance and the
+	// commit like git describe, e.g. 1.3.2-17-g3fa2b1c.
+	SnapshotDescribe SnapshotFormat = "describe"
+)
+
+func ParseSnapshotFormat(s string) (SnapshotFormat, error) {
+	switch format := SnapshotFormat(s); format {
+	case SnapshotDev, SnapshotDescribe:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid snapshot format '%s', expected one of {dev, describe}", s)
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const VersionPlaceholder = "{version}"
+
+type TagFormat struct {
+	Prefix string
+	Suffix string
+}
+
+func ParseTagFormat(format string) (TagFormat, error) {
+	if strings.Count(format, VersionPlaceholder) != 1 {
+		return TagFormat{}, fmt.Errorf("tag format '%s' must contain '%s' exactly once", format, VersionPlaceholder)
+	}
+	split := strings.SplitN(format, VersionPlaceholder, 2)
+	return TagFormat{Prefix: split[0], Suffix: split[1]}, nil
+}
+
+func (f TagFormat) Format(version string) string {
+	return f.Prefix + version + f.Suffix
+}
+
+func (f TagFormat) Extract(tag string) (string, bool) {
+	if !strings.HasPrefix(tag, f.Prefix) || !strings.HasSuffix(tag, f.Suffix) ||
+		len(tag) <= len(f.Prefix)+len(f.Suffix) {
+		return "", false
+	}
+	return tag[len(f.Prefix) : len(tag)-len(f.Suffix)], true
+}
+
+func (f TagFormat) String() string {
+	return f.Format(VersionPlaceholder)
+}
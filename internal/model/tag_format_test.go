@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := ParseTagFormat("api-{version}-final")
+
+	assert.NoError(t, err)
+	assert.Equal(t, TagFormat{Prefix: "api-", Suffix: "-final"}, format)
+	assert.Equal(t, "api-{version}-final", format.String())
+	assert.Equal(t, "api-1.2.3-final", format.Format("1.2.3"))
+}
+
+func TestParseTagFormat_InvalidPlaceholderCount(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"v", "{version}-{version}", "v{Version}"} {
+		_, err := ParseTagFormat(format)
+
+		assert.Error(t, err, format)
+	}
+}
+
+func TestTagFormat_Extract(t *testing.T) {
+	t.Parallel()
+
+	format, err := ParseTagFormat("v{version}")
+	assert.NoError(t, err)
+
+	version, ok := format.Extract("v1.2.3")
+	assert.True(t, ok)
+	assert.Equal(t, "1.2.3", version)
+
+	_, ok = format.Extract("1.2.3")
+	assert.False(t, ok)
+
+	_, ok = format.Extract("v")
+	assert.False(t, ok)
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/pkg/semver"
+)
+
+type VersionRange struct {
+	Major uint64
+	Minor *uint64
+}
+
+func ParseVersionRange(s string) (VersionRange, error) {
+	split := strings.Split(strings.TrimSuffix(strings.TrimPrefix(s, "v"), ".x"), ".")
+	if len(split) > 2 {
+		return VersionRange{}, fmt.Errorf("invalid version range '%s', expected MAJOR.x or MAJOR.MINOR.x", s)
+	}
+
+	var versionRange VersionRange
+	major, err := strconv.ParseUint(split[0], 10, 64)
+	if err != nil {
+		return VersionRange{}, fmt.Errorf("invalid version range '%s', expected MAJOR.x or MAJOR.MINOR.x", s)
+	}
+	versionRange.Major = major
+	if len(split) == 2 {
+		minor, err := strconv.ParseUint(split[1], 10, 64)
+		if err != nil {
+			return VersionRange{}, fmt.Errorf("invalid version range '%s', expected MAJOR.x or MAJOR.MINOR.x", s)
+		}
+		versionRange.Minor = &minor
+	}
+	return versionRange, nil
+}
+
+func (r VersionRange) Contains(version semver.SemVer) bool {
+	return version.Major == r.Major && (r.Minor == nil || version.Minor == *r.Minor)
+}
+
+func (r VersionRange) String() string {
+	if r.Minor == nil {
+		return fmt.Sprintf("%d.x", r.Major)
+	}
+	return fmt.Sprintf("%d.%d.x", r.Major, *r.Minor)
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+type ComponentDocument struct {
+	Name    string   `json:"name"`
+	Reasons []string `json:"reasons"`
+	Document
+}
+
+type ComponentsDocument struct {
+	SchemaVersion int                 `json:"schemaVersion"`
+	Components    []ComponentDocument `json:"components"`
+}
+
+func NewComponentsDocument(results []generator.ComponentResult) ComponentsDocument {
+	document := ComponentsDocument{
+		SchemaVersion: SchemaVersion,
+		Components:    make([]ComponentDocument, 0, len(results)),
+	}
+	for _, result := range results {
+		document.Components = append(document.Components, ComponentDocument{
+			Name:     result.Component.Name,
+			Reasons:  append([]string{}, result.Reasons...),
+			Document: NewDocument(result.Result),
+		})
+	}
+	return document
+}
+
+// WriteComponents writes the next versions of all components. The env and
+// dotenv formats namespace the variables of each component by its name, e.g.
+// AUTOSEMVER_SVC_A_NEXT_VERSION for component svc-a.
+func WriteComponents(w io.Writer, results []generator.ComponentResult, format model.OutputFormat) error {
+	switch format {
+	case model.OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(NewComponentsDocument(results))
+	case model.OutputEnv:
+		return writeComponentVariables(w, NewComponentsDocument(results), "export ")
+	case model.OutputDotenv:
+		return writeComponentVariables(w, NewComponentsDocument(results), "")
+	}
+	for _, result := range results {
+		line := result.Component.Name + " " + result.Result.Tag
+		if len(result.Reasons) > 0 {
+			line += " (" + strings.Join(result.Reasons, "; ") + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeComponentVariables(w io.Writer, document ComponentsDocument, prefix string) error {
+	names := make([]string, 0, len(document.Components))
+	for _, component := range document.Components {
+		names = append(names, component.Name)
+	}
+	variables := []model.Tuple[string, string]{
+		{First: "SCHEMA_VERSION", Second: fmt.Sprint(document.SchemaVersion)},
+		{First: "COMPONENTS", Second: strings.Join(names, " ")},
+	}
+	for _, component := range document.Components {
+		namespace := variableName(component.Name) + "_"
+		variables = append(variables, documentVariables(component.Document, namespace)...)
+		variables = append(variables, model.Tuple[string, string]{First: namespace + "REASONS", Second: strings.Join(component.Reasons, "; ")})
+	}
+	return writeAssignments(w, variables, prefix)
+}
+
+// variableName converts a component name to upper case and replaces characters
+// not allowed in environment variable names with underscores.
+func variableName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
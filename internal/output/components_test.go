@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newComponentResults(t *testing.T) []generator.ComponentResult {
+	t.Helper()
+
+	return []generator.ComponentResult{
+		{Component: model.Component{Name: "svc-a"}, Result: newResult(t), Reasons: []string{"commit 2222222 requires a major bump"}},
+		{Component: model.Component{Name: "lib.x"}, Result: &generator.Result{Tag: "lib.x/v0.0.0", HeadCommit: "abc"}},
+	}
+}
+
+func TestWriteComponents_Text(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := WriteComponents(&b, newComponentResults(t), model.OutputText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "svc-a v2.0.0 (commit 2222222 requires a major bump)\nlib.x lib.x/v0.0.0\n", b.String())
+}
+
+func TestWriteComponents_JSON(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := WriteComponents(&b, newComponentResults(t)[1:], model.OutputJSON)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemaVersion": 1,
+		"components": [{
+			"name": "lib.x",
+			"reasons": [],
+			"schemaVersion": 1,
+			"previousVersion": null,
+			"previousTag": null,
+			"nextVersion": "0.0.0",
+			"nextTag": "lib.x/v0.0.0",
+			"bump": "none",
+			"releaseNeeded": false,
+			"range": {"from": null, "to": "abc"},
+			"commits": []
+		}]
+	}`, b.String())
+}
+
+func TestWriteComponents_Env(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := WriteComponents(&b, newComponentResults(t), model.OutputEnv)
+
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "export AUTOSEMVER_SCHEMA_VERSION=1\nexport AUTOSEMVER_COMPONENTS='svc-a lib.x'\n")
+	assert.Contains(t, b.String(), "export AUTOSEMVER_SVC_A_NEXT_TAG=v2.0.0\n")
+	assert.Contains(t, b.String(), "export AUTOSEMVER_LIB_X_NEXT_TAG=lib.x/v0.0.0\n")
+	assert.Contains(t, b.String(), "export AUTOSEMVER_LIB_X_RELEASE_NEEDED=false\n")
+	assert.Contains(t, b.String(), "export AUTOSEMVER_SVC_A_REASONS='commit 2222222 requires a major bump'\n")
+}
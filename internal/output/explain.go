@@ -0,0 +1,107 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+const maxSubjectLength = 60
+
+// WriteExplanation writes a table of the evaluated commits with the rule
+// determining the bump of each commit, followed by the base of the range and
+// the resulting decision.
+func WriteExplanation(w io.Writer, result *generator.Result) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "HASH\tSUBJECT\tTYPE\tSCOPE\tRULE\tBUMP")
+	for _, commit := range result.Commits {
+		commitType, scope := "-", "-"
+		if commit.Message != nil {
+			commitType = commit.Message.Type
+			if commit.Message.Scope != "" {
+				scope = commit.Message.Scope
+			}
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", shortHash(commit.Hash), truncate(commit.Subject, maxSubjectLength), commitType, scope, commit.Rule, commit.Bump)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	if len(result.Commits) == 0 {
+		fmt.Fprintln(w, "(no commits since the base)")
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	switch {
+	case result.PreviousTag != "":
+		fmt.Fprintf(&b, "Base:     %s (%s)\n", result.PreviousTag, shortHash(result.BaseCommit))
+	case result.BaseCommit != "":
+		fmt.Fprintf(&b, "Base:     %s, no previous release\n", shortHash(result.BaseCommit))
+	default:
+		b.WriteString("Base:     none, all commits since the initial commit\n")
+	}
+	fmt.Fprintf(&b, "Head:     %s\n", shortHash(result.HeadCommit))
+	fmt.Fprintf(&b, "Bump:     %s", result.Bump)
+	if result.BumpCause != "" {
+		fmt.Fprintf(&b, ", determined by %s", shortHash(result.BumpCause))
+	}
+	if result.BumpNote != "" {
+		fmt.Fprintf(&b, ", %s", result.BumpNote)
+	} else if result.BumpCause == "" {
+		b.WriteString(", no commit requires a release")
+	}
+	b.WriteString("\n")
+	previous := result.PreviousTag
+	if previous == "" {
+		previous = "none"
+	}
+	if result.Bump == model.BumpNone {
+		fmt.Fprintf(&b, "Decision: no release needed (%s)\n", result.Tag)
+	} else {
+		fmt.Fprintf(&b, "Decision: %s -> %s\n", previous, result.Tag)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteComponentExplanations writes the explanation of every component.
+func WriteComponentExplanations(w io.Writer, results []generator.ComponentResult) error {
+	for i, result := range results {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "Component %s (paths: %s)\n\n", result.Component.Name, strings.Join(result.Component.Paths, ", ")); err != nil {
+			return err
+		}
+		if err := WriteExplanation(w, result.Result); err != nil {
+			return err
+		}
+		for _, reason := range result.Reasons {
+			if _, err := fmt.Fprintf(w, "Reason:   %s\n", reason); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func truncate(s string, length int) string {
+	if runes := []rune(s); len(runes) > length {
+		return string(runes[:length-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteExplanation(t *testing.T) {
+	t.Parallel()
+
+	result := newResult(t)
+	result.Commits[0].Rule = "breaking change (!)"
+	result.Commits[1].Rule = "not conventional: header is missing the ': ' separator between type and description"
+	result.BumpCause = result.Commits[0].Hash
+	var b bytes.Buffer
+	err := WriteExplanation(&b, result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `HASH     SUBJECT              TYPE  SCOPE  RULE                                                                                 BUMP
+2222222  feat(api)!: new api  feat  api    breaking change (!)                                                                  major
+3333333  update readme        -     -      not conventional: header is missing the ': ' separator between type and description  none
+
+Base:     v1.2.3 (1111111)
+Head:     2222222
+Bump:     major, determined by 2222222
+Decision: v1.2.3 -> v2.0.0
+`, b.String())
+}
+
+func TestWriteExplanation_NoRelease(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := WriteExplanation(&b, &generator.Result{Tag: "0.0.0", HeadCommit: "abcdef0123"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "(no commits since the base)\n")
+	assert.Contains(t, b.String(), "Base:     none, all commits since the initial commit\n")
+	assert.Contains(t, b.String(), "Bump:     none, no commit requires a release\n")
+	assert.Contains(t, b.String(), "Decision: no release needed (0.0.0)\n")
+}
+
+func TestWriteComponentExplanations(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := WriteComponentExplanations(&b, []generator.ComponentResult{{
+		Component: model.Component{Name: "svc", Paths: []string{"svc"}},
+		Result:    &generator.Result{Tag: "svc/v1.0.1", PreviousTag: "svc/v1.0.0", Bump: model.BumpPatch, BumpNote: "raised to the minimum bump"},
+		Reasons:   []string{"dependency libs is released as libs/v1.1.0"},
+	}})
+
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "Component svc (paths: svc)\n\n")
+	assert.Contains(t, b.String(), "Bump:     patch, raised to the minimum bump\n")
+	assert.Contains(t, b.String(), "Decision: svc/v1.0.0 -> svc/v1.0.1\n")
+	assert.Contains(t, b.String(), "Reason:   dependency libs is released as libs/v1.1.0\n")
+}
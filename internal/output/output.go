@@ -0,0 +1,146 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+)
+
+// SchemaVersion is increased on every incompatible change of the JSON, env
+// and dotenv outputs.
+const SchemaVersion = 1
+
+type Range struct {
+	From *string `json:"from"`
+	To   string  `json:"to"`
+}
+
+type Commit struct {
+	Hash     string `json:"hash"`
+	Subject  string `json:"subject"`
+	Type     string `json:"type,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Bump     string `json:"bump"`
+	// Cancelled is set for reverted commits and the commits reverting them.
+	Cancelled bool `json:"cancelled,omitempty"`
+}
+
+type Document struct {
+	SchemaVersion   int      `json:"schemaVersion"`
+	PreviousVersion *string  `json:"previousVersion"`
+	PreviousTag     *string  `json:"previousTag"`
+	NextVersion     string   `json:"nextVersion"`
+	NextTag         string   `json:"nextTag"`
+	Bump            string   `json:"bump"`
+	ReleaseNeeded   bool     `json:"releaseNeeded"`
+	Range           Range    `json:"range"`
+	Commits         []Commit `json:"commits"`
+}
+
+func NewDocument(result *generator.Result) Document {
+	document := Document{
+		SchemaVersion: SchemaVersion,
+		NextVersion:   result.Version.String(),
+		NextTag:       result.Tag,
+		Bump:          result.Bump.String(),
+		ReleaseNeeded: result.Bump != model.BumpNone,
+		Range:         Range{To: result.HeadCommit},
+		Commits:       make([]Commit, 0, len(result.Commits)),
+	}
+	if result.PreviousVersion != nil {
+		previousVersion, previousTag := result.PreviousVersion.String(), result.PreviousTag
+		document.PreviousVersion = &previousVersion
+		document.PreviousTag = &previousTag
+	}
+	if result.BaseCommit != "" {
+		from := result.BaseCommit
+		document.Range.From = &from
+	}
+	for _, c := range result.Commits {
+		commit := Commit{Hash: c.Hash, Subject: c.Subject, Bump: c.Bump.String(), Cancelled: c.Cancelled}
+		if c.Message != nil {
+			commit.Type = c.Message.Type
+			commit.Scope = c.Message.Scope
+			commit.Breaking = c.Message.IsBreaking()
+		}
+		document.Commits = append(document.Commits, commit)
+	}
+	return document
+}
+
+func Write(w io.Writer, result *generator.Result, format model.OutputFormat) error {
+	switch format {
+	case model.OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(NewDocument(result))
+	case model.OutputEnv:
+		return writeVariables(w, NewDocument(result), "export ")
+	case model.OutputDotenv:
+		return writeVariables(w, NewDocument(result), "")
+	}
+	_, err := fmt.Fprintln(w, result.Tag)
+	return err
+}
+
+func writeVariables(w io.Writer, document Document, prefix string) error {
+	variables := append([]model.Tuple[string, string]{
+		{First: "SCHEMA_VERSION", Second: fmt.Sprint(document.SchemaVersion)},
+	}, documentVariables(document, "")...)
+	return writeAssignments(w, variables, prefix)
+}
+
+func documentVariables(document Document, namespace string) []model.Tuple[string, string] {
+	hashes := make([]string, 0, len(document.Commits))
+	for _, commit := range document.Commits {
+		hashes = append(hashes, commit.Hash)
+	}
+	variables := []model.Tuple[string, string]{
+		{First: "PREVIOUS_VERSION", Second: valueOrEmpty(document.PreviousVersion)},
+		{First: "PREVIOUS_TAG", Second: valueOrEmpty(document.PreviousTag)},
+		{First: "NEXT_VERSION", Second: document.NextVersion},
+		{First: "NEXT_TAG", Second: document.NextTag},
+		{First: "BUMP", Second: document.Bump},
+		{First: "RELEASE_NEEDED", Second: fmt.Sprint(document.ReleaseNeeded)},
+		{First: "RANGE_FROM", Second: valueOrEmpty(document.Range.From)},
+		{First: "RANGE_TO", Second: document.Range.To},
+		{First: "COMMIT_COUNT", Second: fmt.Sprint(len(document.Commits))},
+		{First: "COMMITS", Second: strings.Join(hashes, " ")},
+	}
+	for i := range variables {
+		variables[i].First = namespace + variables[i].First
+	}
+	return variables
+}
+
+func writeAssignments(w io.Writer, variables []model.Tuple[string, string], prefix string) error {
+	for _, variable := range variables {
+		if _, err := fmt.Fprintf(w, "%sAUTOSEMVER_%s=%s\n", prefix, variable.First, quote(variable.Second)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// quote wraps values in single quotes if they contain characters that are
+// special to shells or dotenv parsers.
+func quote(s string) string {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._-+/", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StevenCyb/autosemver/internal/conventional"
+	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResult(t *testing.T) *generator.Result {
+	t.Helper()
+
+	previous := semver.MustParse("1.2.3")
+	feat, err := conventional.Parse("feat(api)!: new api")
+	assert.NoError(t, err)
+	return &generator.Result{
+		PreviousTag:     "v1.2.3",
+		PreviousVersion: &previous,
+		Tag:             "v2.0.0",
+		Version:         semver.MustParse("2.0.0"),
+		Bump:            model.BumpMajor,
+		BaseCommit:      "1111111111111111111111111111111111111111",
+		HeadCommit:      "2222222222222222222222222222222222222222",
+		Commits: []generator.Commit{
+			{Hash: "2222222222222222222222222222222222222222", Subject: "feat(api)!: new api", Message: feat, Bump: model.BumpMajor},
+			{Hash: "3333333333333333333333333333333333333333", Subject: "update readme"},
+		},
+	}
+}
+
+func TestWrite_Text(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Write(&b, newResult(t), model.OutputText)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.0.0\n", b.String())
+}
+
+func TestWrite_JSON(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Write(&b, newResult(t), model.OutputJSON)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemaVersion": 1,
+		"previousVersion": "1.2.3",
+		"previousTag": "v1.2.3",
+		"nextVersion": "2.0.0",
+		"nextTag": "v2.0.0",
+		"bump": "major",
+		"releaseNeeded": true,
+		"range": {
+			"from": "1111111111111111111111111111111111111111",
+			"to": "2222222222222222222222222222222222222222"
+		},
+		"commits": [
+			{"hash": "2222222222222222222222222222222222222222", "subject": "feat(api)!: new api", "type": "feat", "scope": "api", "breaking": true, "bump": "major"},
+			{"hash": "3333333333333333333333333333333333333333", "subject": "update readme", "breaking": false, "bump": "none"}
+		]
+	}`, b.String())
+}
+
+func TestWrite_JSON_NoPreviousVersion(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Write(&b, &generator.Result{Tag: "0.0.0", HeadCommit: "abc"}, model.OutputJSON)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemaVersion": 1,
+		"previousVersion": null,
+		"previousTag": null,
+		"nextVersion": "0.0.0",
+		"nextTag": "0.0.0",
+		"bump": "none",
+		"releaseNeeded": false,
+		"range": {"from": null, "to": "abc"},
+		"commits": []
+	}`, b.String())
+}
+
+func TestWrite_Env(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Write(&b, newResult(t), model.OutputEnv)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `export AUTOSEMVER_SCHEMA_VERSION=1
+export AUTOSEMVER_PREVIOUS_VERSION=1.2.3
+export AUTOSEMVER_PREVIOUS_TAG=v1.2.3
+export AUTOSEMVER_NEXT_VERSION=2.0.0
+export AUTOSEMVER_NEXT_TAG=v2.0.0
+export AUTOSEMVER_BUMP=major
+export AUTOSEMVER_RELEASE_NEEDED=true
+export AUTOSEMVER_RANGE_FROM=1111111111111111111111111111111111111111
+export AUTOSEMVER_RANGE_TO=2222222222222222222222222222222222222222
+export AUTOSEMVER_COMMIT_COUNT=2
+export AUTOSEMVER_COMMITS='2222222222222222222222222222222222222222 3333333333333333333333333333333333333333'
+`, b.String())
+}
+
+func TestWrite_Dotenv(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	err := Write(&b, &generator.Result{Tag: "it's", HeadCommit: "abc"}, model.OutputDotenv)
+
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "AUTOSEMVER_PREVIOUS_VERSION=\n")
+	assert.Contains(t, b.String(), "AUTOSEMVER_NEXT_TAG='it'\\''s'\n")
+	assert.NotContains(t, b.String(), "export")
+}
+
+func TestNewDocument_CancelledCommit(t *testing.T) {
+	t.Parallel()
+
+	result := newResult(t)
+	result.Commits[0].Cancelled = true
+	document := NewDocument(result)
+
+	assert.True(t, document.Commits[0].Cancelled)
+	assert.False(t, document.Commits[1].Cancelled)
+}
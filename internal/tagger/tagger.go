@@ -0,0 +1,91 @@
+package tagger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/StevenCyb/autosemver/internal/worktree"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const DefaultMessage = "Release {tag}"
+
+type Options struct {
+	Annotated bool
+	Message   string
+	Remote    string
+	Log       logger.Logger
+}
+
+func CreateTag(repositoryPath string, tag string, hash string, opts Options) error {
+	repo, err := git.PlainOpen(repositoryPath)
+	if err != nil {
+		return err
+	}
+	return createTag(repo, tag, plumbing.NewHash(hash), opts)
+}
+
+func createTag(repo *git.Repository, tag string, hash plumbing.Hash, opts Options) error {
+	if _, err := repo.Tag(tag); err == nil {
+		return fmt.Errorf("tag %s already exists", tag)
+	} else if !errors.Is(err, git.ErrTagNotFound) {
+		return err
+	}
+
+	dirty, err := worktree.IsDirty(repo)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("worktree has uncommitted changes, refusing to create tag %s", tag)
+	}
+
+	var tagOpts *git.CreateTagOptions
+	if opts.Annotated {
+		message := opts.Message
+		if message == "" {
+			message = DefaultMessage
+		}
+		tagger, err := signature(repo)
+		if err != nil {
+			return err
+		}
+		tagOpts = &git.CreateTagOptions{
+			Tagger:  tagger,
+			Message: strings.ReplaceAll(message, "{tag}", tag),
+		}
+	}
+	opts.Log.Printf("Creating tag %s on commit %s\n", tag, hash.String())
+	if _, err := repo.CreateTag(tag, hash, tagOpts); err != nil {
+		return err
+	}
+
+	if opts.Remote != "" {
+		opts.Log.Printf("Pushing tag %s to %s\n", tag, opts.Remote)
+		refSpec := config.RefSpec(fmt.Sprintf("refs/tags/%s:refs/tags/%s", tag, tag))
+		err := repo.Push(&git.PushOptions{RemoteName: opts.Remote, RefSpecs: []config.RefSpec{refSpec}})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return fmt.Errorf("failed to push tag %s to %s: %w", tag, opts.Remote, err)
+		}
+	}
+	return nil
+}
+
+func signature(repo *git.Repository) (*object.Signature, error) {
+	cfg, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return nil, err
+	}
+	sig := &object.Signature{Name: cfg.User.Name, Email: cfg.User.Email, When: time.Now()}
+	if sig.Name == "" {
+		sig.Name = "autosemver"
+	}
+	return sig, nil
+}
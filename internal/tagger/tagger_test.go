@@ -0,0 +1,143 @@
+package tagger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/StevenCyb/autosemver/internal/logger"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	client.InstallProtocol("file", server.NewClient(server.DefaultLoader))
+}
+
+func newRepository(t *testing.T) (*git.Repository, billy.Filesystem, plumbing.Hash) {
+	t.Helper()
+
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	f, err := fs.Create("README.md")
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("content"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	_, err = wt.Add("README.md")
+	assert.NoError(t, err)
+	hash, err := wt.Commit("feat: init", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test Bot", Email: "test@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+
+	return repo, fs, hash
+}
+
+func TestCreateTag_Lightweight(t *testing.T) {
+	t.Parallel()
+
+	repo, _, hash := newRepository(t)
+	err := createTag(repo, "v1.0.0", hash, Options{Log: logger.Silent{}})
+
+	assert.NoError(t, err)
+	ref, err := repo.Tag("v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, hash, ref.Hash())
+}
+
+func TestCreateTag_Annotated(t *testing.T) {
+	t.Parallel()
+
+	repo, _, hash := newRepository(t)
+	err := createTag(repo, "v1.0.0", hash, Options{Annotated: true, Message: "Version {tag}", Log: logger.Silent{}})
+
+	assert.NoError(t, err)
+	ref, err := repo.Tag("v1.0.0")
+	assert.NoError(t, err)
+	tag, err := repo.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, "Version v1.0.0\n", tag.Message)
+	assert.Equal(t, hash, tag.Target)
+}
+
+func TestCreateTag_AlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	repo, _, hash := newRepository(t)
+	_, err := repo.CreateTag("v1.0.0", hash, nil)
+	assert.NoError(t, err)
+	err = createTag(repo, "v1.0.0", hash, Options{Log: logger.Silent{}})
+
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestCreateTag_DirtyWorktree(t *testing.T) {
+	t.Parallel()
+
+	repo, fs, hash := newRepository(t)
+	f, err := fs.Create("README.md")
+	assert.NoError(t, err)
+	_, err = f.Write([]byte("changed"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	err = createTag(repo, "v1.0.0", hash, Options{Log: logger.Silent{}})
+
+	assert.ErrorContains(t, err, "uncommitted changes")
+	_, err = repo.Tag("v1.0.0")
+	assert.ErrorIs(t, err, git.ErrTagNotFound)
+}
+
+func TestCreateTag_UntrackedFile(t *testing.T) {
+	t.Parallel()
+
+	repo, fs, hash := newRepository(t)
+	f, err := fs.Create("build.out")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	err = createTag(repo, "v1.0.0", hash, Options{Log: logger.Silent{}})
+
+	assert.NoError(t, err)
+	_, err = repo.Tag("v1.0.0")
+	assert.NoError(t, err)
+}
+
+func TestCreateTag_PushToRemote(t *testing.T) {
+	t.Parallel()
+
+	remotePath := t.TempDir()
+	remote, err := git.PlainInit(remotePath, true)
+	assert.NoError(t, err)
+	repo, _, hash := newRepository(t)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	assert.NoError(t, err)
+	err = repo.Push(&git.PushOptions{RemoteName: "origin", RefSpecs: []config.RefSpec{"refs/heads/master:refs/heads/master"}})
+	assert.NoError(t, err)
+	err = createTag(repo, "v1.0.0", hash, Options{Annotated: true, Remote: "origin", Log: logger.Silent{}})
+
+	assert.NoError(t, err)
+	ref, err := remote.Tag("v1.0.0")
+	assert.NoError(t, err)
+	tag, err := remote.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, hash, tag.Target)
+}
+
+func TestCreateTag_PushToUnknownRemote(t *testing.T) {
+	t.Parallel()
+
+	repo, _, hash := newRepository(t)
+	err := createTag(repo, "v1.0.0", hash, Options{Remote: "upstream", Log: logger.Silent{}})
+
+	assert.Error(t, err)
+}
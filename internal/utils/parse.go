@@ -1,14 +0,0 @@
-package utils
-
-import (
-	"log"
-	"strconv"
-)
-
-func MustParseUint(s string) uint {
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return uint(i)
-}
@@ -0,0 +1,33 @@
+// Package worktree inspects the working tree of a repository.
+package worktree
+
+import (
+	"errors"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// IsDirty reports whether tracked files have uncommitted changes, staged or
+// not. Untracked files are ignored like by `git describe --dirty`, and bare
+// repositories are never dirty.
+func IsDirty(repo *git.Repository) (bool, error) {
+	wt, err := repo.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	for _, file := range status {
+		if file.Worktree == git.Untracked {
+			continue
+		}
+		if file.Staging != git.Unmodified || file.Worktree != git.Unmodified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
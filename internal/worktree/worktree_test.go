@@ -0,0 +1,88 @@
+package worktree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepository(t *testing.T) (*git.Repository, billy.Filesystem) {
+	t.Helper()
+
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	writeFile(t, fs, "README.md", "content")
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	_, err = wt.Add("README.md")
+	assert.NoError(t, err)
+	_, err = wt.Commit("feat: init", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test Bot", Email: "test@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+	return repo, fs
+}
+
+func writeFile(t *testing.T, fs billy.Filesystem, name, content string) {
+	t.Helper()
+
+	f, err := fs.Create(name)
+	assert.NoError(t, err)
+	_, err = f.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestIsDirty_Clean(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := newRepository(t)
+	dirty, err := IsDirty(repo)
+
+	assert.NoError(t, err)
+	assert.False(t, dirty)
+}
+
+func TestIsDirty_UntrackedFile(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := newRepository(t)
+	writeFile(t, fs, "build.out", "binary")
+	dirty, err := IsDirty(repo)
+
+	assert.NoError(t, err)
+	assert.False(t, dirty)
+}
+
+func TestIsDirty_ModifiedFile(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := newRepository(t)
+	writeFile(t, fs, "README.md", "changed")
+	dirty, err := IsDirty(repo)
+
+	assert.NoError(t, err)
+	assert.True(t, dirty)
+}
+
+func TestIsDirty_StagedFile(t *testing.T) {
+	t.Parallel()
+
+	repo, fs := newRepository(t)
+	writeFile(t, fs, "main.go", "package main")
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	_, err = wt.Add("main.go")
+	assert.NoError(t, err)
+	dirty, err := IsDirty(repo)
+
+	assert.NoError(t, err)
+	assert.True(t, dirty)
+}
@@ -2,30 +2,44 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/StevenCyb/autosemver/internal/changelog"
+	"github.com/StevenCyb/autosemver/internal/config"
 	"github.com/StevenCyb/autosemver/internal/generator"
+	"github.com/StevenCyb/autosemver/internal/gomod"
+	"github.com/StevenCyb/autosemver/internal/hooks"
+	"github.com/StevenCyb/autosemver/internal/lint"
 	"github.com/StevenCyb/autosemver/internal/logger"
 	"github.com/StevenCyb/autosemver/internal/model"
+	"github.com/StevenCyb/autosemver/internal/output"
+	"github.com/StevenCyb/autosemver/internal/tagger"
 )
 
 const version = "1.0.0"
 
 var errorExitCode = 1
-var ignoreInvalidTags = false
-var asRC = false
+var cfg = config.Default()
+var configPath = ""
+var branch = ""
+var allReleases = false
+var componentName = ""
+var explain = false
+var promoteToStable = false
+var bumpOverride *model.Bump
+var ref, fromRef, target = "", "", ""
+var snapshot model.SnapshotFormat
+var lintFrom, lintTo, lintMessageFile = "", "", ""
+var hookNames = []string{hooks.CommitMsg}
+var hookOpts = hooks.Options{}
+var tagOpts = tagger.Options{}
 var log logger.Logger = logger.Silent{}
-var conventionalCommitToSemVer = []model.Tuple[string, string]{
-	{First: "breaking change", Second: "major"},
-	{First: "fix!", Second: "major"},
-	{First: "feat!", Second: "major"},
-	{First: "feat", Second: "minor"},
-	{First: "pref", Second: "patch"},
-	{First: "fix", Second: "patch"},
-}
 
 func main() {
+	command := ""
 	repoPath := "."
 	if len(os.Args) > 1 {
 		args := os.Args[1:]
@@ -36,9 +50,36 @@ func main() {
 		} else if args[0] == "help" {
 			printHelp()
 			os.Exit(0)
+		} else if args[0] == "changelog" || args[0] == "tag" || args[0] == "components" || args[0] == "modules" || args[0] == "apidiff" || args[0] == "lint" {
+			command = args[0]
+			args = args[1:]
+		} else if args[0] == "hooks" {
+			if len(args) < 2 || (args[1] != "install" && args[1] != "uninstall") {
+				fmt.Fprintln(os.Stderr, "Error: unknown hooks command, expected 'hooks install' or 'hooks uninstall'")
+				printHelp()
+				os.Exit(errorExitCode)
+			}
+			command = "hooks " + args[1]
+			args = args[2:]
+		} else if args[0] == "gomod" {
+			if len(args) < 2 || args[1] != "fix" {
+				fmt.Fprintln(os.Stderr, "Error: unknown gomod command, expected 'gomod fix'")
+				printHelp()
+				os.Exit(errorExitCode)
+			}
+			command = "gomod fix"
+			args = args[2:]
+		} else if args[0] == "config" {
+			if len(args) < 2 || args[1] != "validate" {
+				fmt.Fprintln(os.Stderr, "Error: unknown config command, expected 'config validate'")
+				printHelp()
+				os.Exit(errorExitCode)
+			}
+			command = "config validate"
+			args = args[2:]
 		}
 
-		if !strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
 			repoPath = args[0]
 			args = args[1:]
 			if _, err := os.Stat(repoPath); os.IsNotExist(err) {
@@ -48,14 +89,151 @@ func main() {
 		}
 
 		for _, arg := range args {
-			if arg == "--disable-exit-1" {
+			if strings.HasPrefix(arg, "--config=") || strings.HasPrefix(arg, "-c=") {
+				configPath = strings.TrimPrefix(arg, "--config=")
+				configPath = strings.TrimPrefix(configPath, "-c=")
+			}
+		}
+		if command != "config validate" {
+			loadConfig(repoPath)
+		}
+
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--config=") || strings.HasPrefix(arg, "-c=") {
+				continue
+			} else if arg == "--disable-exit-1" {
+				cfg.DisableExit1 = true
 				errorExitCode = 0
 			} else if arg == "--verbose" || arg == "-v" {
-				log = logger.Verbose{}
+				cfg.Verbose = true
+			} else if arg == "--explain" {
+				explain = true
+			} else if arg == "--initial-development" {
+				cfg.InitialDevelopment = true
+			} else if arg == "--promote-to-stable" {
+				promoteToStable = true
+			} else if strings.HasPrefix(arg, "--merge-strategy=") {
+				strategy, err := model.ParseMergeStrategy(strings.TrimPrefix(arg, "--merge-strategy="))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+				cfg.MergeStrategy = strategy
+			} else if arg == "--squash-commits" {
+				cfg.SquashCommits = true
+			} else if arg == "--first-parent" {
+				cfg.FirstParent = true
+			} else if strings.HasPrefix(arg, "--bump=") {
+				bump, err := model.ParseBump(strings.TrimPrefix(arg, "--bump="))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+				bumpOverride = &bump
 			} else if arg == "--release-candidate" || arg == "-r" {
-				asRC = true
+				cfg.PreRelease = "rc"
+			} else if strings.HasPrefix(arg, "--pre-release=") || strings.HasPrefix(arg, "-p=") {
+				cfg.PreRelease = strings.TrimPrefix(arg, "--pre-release=")
+				cfg.PreRelease = strings.TrimPrefix(cfg.PreRelease, "-p=")
+				if err := model.ValidateChannel(cfg.PreRelease); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
 			} else if arg == "--ignore-invalid-tag" || arg == "-i" {
-				ignoreInvalidTags = true
+				cfg.IgnoreInvalidTags = true
+			} else if strings.HasPrefix(arg, "--tag-format=") || strings.HasPrefix(arg, "-t=") {
+				format := strings.TrimPrefix(arg, "--tag-format=")
+				format = strings.TrimPrefix(format, "-t=")
+				var err error
+				cfg.TagFormat, err = model.ParseTagFormat(format)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+			} else if strings.HasPrefix(arg, "--branch-rule=") || strings.HasPrefix(arg, "-b=") {
+				rule := strings.TrimPrefix(arg, "--branch-rule=")
+				rule = strings.TrimPrefix(rule, "-b=")
+				branchRule, err := model.ParseBranchRule(rule)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+				cfg.BranchRules = append([]model.BranchRule{branchRule}, cfg.BranchRules...)
+			} else if strings.HasPrefix(arg, "--component=") && command != "components" && command != "modules" {
+				componentName = strings.TrimPrefix(arg, "--component=")
+			} else if strings.HasPrefix(arg, "--branch=") {
+				branch = strings.TrimPrefix(arg, "--branch=")
+			} else if arg == "--all" && command == "changelog" {
+				allReleases = true
+			} else if (arg == "--annotate" || arg == "-a") && command == "tag" {
+				tagOpts.Annotated = true
+			} else if strings.HasPrefix(arg, "--message=") && command == "tag" {
+				tagOpts.Annotated = true
+				tagOpts.Message = strings.TrimPrefix(arg, "--message=")
+			} else if (arg == "--push" || strings.HasPrefix(arg, "--push=")) && command == "tag" {
+				tagOpts.Remote = "origin"
+				if remote := strings.TrimPrefix(arg, "--push="); remote != arg {
+					tagOpts.Remote = remote
+				}
+			} else if strings.HasPrefix(arg, "--go-module-check=") {
+				var err error
+				cfg.GoModuleCheck, err = model.ParseCheckLevel(strings.TrimPrefix(arg, "--go-module-check="))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+			} else if strings.HasPrefix(arg, "--api-check=") {
+				var err error
+				cfg.APICheck, err = model.ParseCheckLevel(strings.TrimPrefix(arg, "--api-check="))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+			} else if strings.HasPrefix(arg, "--from=") && command == "lint" {
+				lintFrom = strings.TrimPrefix(arg, "--from=")
+			} else if strings.HasPrefix(arg, "--to=") && command == "lint" {
+				lintTo = strings.TrimPrefix(arg, "--to=")
+			} else if strings.HasPrefix(arg, "--message-file=") && command == "lint" {
+				lintMessageFile = strings.TrimPrefix(arg, "--message-file=")
+			} else if strings.HasPrefix(arg, "--ref=") {
+				ref = strings.TrimPrefix(arg, "--ref=")
+			} else if strings.HasPrefix(arg, "--from=") {
+				fromRef = strings.TrimPrefix(arg, "--from=")
+			} else if strings.HasPrefix(arg, "--target=") {
+				target = strings.TrimPrefix(arg, "--target=")
+			} else if arg == "--snapshot" || strings.HasPrefix(arg, "--snapshot=") {
+				format := strings.TrimPrefix(strings.TrimPrefix(arg, "--snapshot"), "=")
+				if format == "" {
+					format = string(model.SnapshotDev)
+				}
+				var err error
+				snapshot, err = model.ParseSnapshotFormat(format)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+			} else if arg == "--pre-push" && command == "hooks install" {
+				hookNames = append(hookNames, hooks.PrePush)
+			} else if strings.HasPrefix(arg, "--executable=") && command == "hooks install" {
+				hookOpts.Executable = strings.TrimPrefix(arg, "--executable=")
+			} else if strings.HasPrefix(arg, "--output=") || strings.HasPrefix(arg, "-o=") {
+				format := strings.TrimPrefix(arg, "--output=")
+				format = strings.TrimPrefix(format, "-o=")
+				var err error
+				cfg.Output, err = model.ParseOutputFormat(format)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
 			} else if arg == "--help" || arg == "-h" {
 				printHelp()
 				os.Exit(0)
@@ -63,53 +241,368 @@ func main() {
 				mapping := strings.TrimPrefix(arg, "--mapping=")
 				mapping = strings.TrimPrefix(mapping, "-m=")
 				splitMapping := strings.Split(mapping, ":")
-				if len(splitMapping) != 2 || len(splitMapping[0]) == 0 || len(splitMapping[1]) == 0 ||
-					(splitMapping[1] != "major" && splitMapping[1] != "minor" && splitMapping[1] != "patch") {
+				if len(splitMapping) != 2 || len(splitMapping[0]) == 0 || len(splitMapping[1]) == 0 {
 					fmt.Fprintf(os.Stderr, "Error: invalid mapping format '%s'\n", mapping)
 					printHelp()
 					os.Exit(errorExitCode)
 				}
-				conventionalCommitToSemVer = append(conventionalCommitToSemVer, model.Tuple[string, string]{First: splitMapping[0], Second: splitMapping[1]})
+				bump, err := model.ParseBump(splitMapping[1])
+				if err != nil || bump == model.BumpNone {
+					fmt.Fprintf(os.Stderr, "Error: invalid mapping format '%s'\n", mapping)
+					printHelp()
+					os.Exit(errorExitCode)
+				}
+				cfg.Mappings = append([]model.Tuple[string, model.Bump]{{First: splitMapping[0], Second: bump}}, cfg.Mappings...)
 			} else {
 				fmt.Fprintf(os.Stderr, "Error: unknown option '%s'\n", arg)
 				printHelp()
 				os.Exit(errorExitCode)
 			}
 		}
+	} else {
+		loadConfig(repoPath)
 	}
 
-	if !asRC {
-		version, err := generator.FindNextVersion(repoPath, conventionalCommitToSemVer, log, ignoreInvalidTags)
+	if cfg.Verbose {
+		log = logger.Verbose{}
+	}
+	opts := generator.Options{
+		IncMapping:           cfg.Mappings,
+		Log:                  log,
+		IgnoreInvalidTags:    cfg.IgnoreInvalidTags,
+		TagFormat:            cfg.TagFormat,
+		BranchRules:          cfg.BranchRules,
+		Branch:               branch,
+		DiscoverDependencies: cfg.DiscoverDependencies,
+		GoModuleCheck:        cfg.GoModuleCheck,
+		APICheck:             cfg.APICheck,
+		InitialDevelopment:   cfg.InitialDevelopment,
+		PromoteToStable:      promoteToStable,
+		Bump:                 bumpOverride,
+		MergeStrategy:        cfg.MergeStrategy,
+		SquashCommits:        cfg.SquashCommits,
+		FirstParent:          cfg.FirstParent,
+		Ref:                  ref,
+		From:                 fromRef,
+		Target:               target,
+	}
+	switch command {
+	case "config validate":
+		path, ok := configPath, configPath != ""
+		if !ok {
+			path, ok = config.Find(repoPath)
+		}
+		if !ok {
+			exitOnError(fmt.Errorf("no configuration file %s found in %s", strings.Join(config.FileNames, " or "), repoPath))
+		}
+		exitOnError(config.Load(path, config.Default()))
+		fmt.Printf("Configuration %s is valid\n", path)
+	case "changelog":
+		var releases []generator.Release
+		result, err := findNextRelease(repoPath, opts)
+		exitOnError(err)
+		if result.Bump != model.BumpNone {
+			releases = append(releases, result.Release())
+		}
+		if allReleases {
+			history, err := generator.FindReleaseHistory(repoPath, componentOptions(opts))
+			exitOnError(err)
+			releases = append(releases, history...)
+		}
+		exitOnError(changelog.Render(os.Stdout, releases))
+	case "tag":
+		if target != "" {
+			exitOnError(fmt.Errorf("cannot tag a predicted merge into %s", target))
+		}
+		if snapshot != "" {
+			exitOnError(fmt.Errorf("cannot tag a snapshot version"))
+		}
+		result, err := findNextRelease(repoPath, opts)
+		exitOnError(err)
+		if result.PreviousTag != "" && result.BaseCommit == result.HeadCommit {
+			exitOnError(fmt.Errorf("HEAD is already tagged as %s", result.PreviousTag))
+		}
+		tagOpts.Log = log
+		exitOnError(tagger.CreateTag(repoPath, result.Tag, result.HeadCommit, tagOpts))
+		exitOnError(output.Write(os.Stdout, result, cfg.Output))
+	case "components":
+		if len(cfg.Components) == 0 {
+			exitOnError(fmt.Errorf("no components configured"))
+		}
+		results, err := generator.FindComponentVersions(repoPath, cfg.Components, cfg.PreRelease, opts)
+		exitOnError(err)
+		printComponentWarnings(results)
+		if explain {
+			exitOnError(output.WriteComponentExplanations(os.Stderr, results))
+		}
+		exitOnError(output.WriteComponents(os.Stdout, results, cfg.Output))
+	case "modules":
+		modules, err := generator.FindGoModules(repoPath, opts)
+		exitOnError(err)
+		results, err := generator.FindComponentVersions(repoPath, modules, cfg.PreRelease, opts)
+		exitOnError(err)
+		printComponentWarnings(results)
+		if explain {
+			exitOnError(output.WriteComponentExplanations(os.Stderr, results))
+		}
+		exitOnError(output.WriteComponents(os.Stdout, results, cfg.Output))
+	case "lint":
+		rules := lint.Rules{Types: append([]string{}, cfg.LintTypes...)}
+		for _, mapping := range cfg.Mappings {
+			rules.Types = append(rules.Types, mapping.First)
+		}
+		if lintMessageFile != "" {
+			exitOnError(lintMessage(lintMessageFile, rules))
+			break
+		}
+		commits, err := generator.FindCommits(repoPath, lintFrom, lintTo, opts)
+		exitOnError(err)
+		violations := 0
+		for _, commit := range commits {
+			problems := lint.Message(commit.Raw, rules)
+			if len(problems) == 0 {
+				continue
+			}
+			violations++
+			fmt.Printf("%s %s\n", commit.Hash[:7], commit.Subject)
+			for _, problem := range problems {
+				fmt.Printf("\t%s\n", problem)
+			}
+		}
+		if violations > 0 {
+			exitOnError(fmt.Errorf("%d of %d commits are not valid conventional commits", violations, len(commits)))
+		}
+		fmt.Printf("All %d commits are valid conventional commits\n", len(commits))
+	case "hooks install":
+		hookOpts.Log = log
+		installed, err := hooks.Install(repoPath, hookNames, hookOpts)
+		for _, path := range installed {
+			fmt.Printf("Installed %s\n", path)
+		}
+		exitOnError(err)
+	case "hooks uninstall":
+		hookOpts.Log = log
+		removed, err := hooks.Uninstall(repoPath, nil, hookOpts)
+		for _, path := range removed {
+			fmt.Printf("Removed %s\n", path)
+		}
+		exitOnError(err)
+	case "apidiff":
+		opts.GoModuleCheck = model.CheckOff
+		result, reports, err := generator.FindAPIChanges(repoPath, componentOptions(opts))
+		exitOnError(err)
+		if result.PreviousTag == "" {
+			exitOnError(fmt.Errorf("no previous release to compare the API with"))
+		}
+		required := model.BumpNone
+		for _, report := range reports {
+			fmt.Printf("Module %s (compared with %s):\n", report.Dir, result.PreviousTag)
+			if len(report.Report.Changes) == 0 {
+				fmt.Println("\tno changes")
+			}
+			for _, change := range report.Report.Changes {
+				kind := "compatible"
+				if change.Incompatible {
+					kind = "incompatible"
+				}
+				fmt.Printf("\t%s: %s\n", kind, change)
+			}
+			required = max(required, report.Report.Bump())
+		}
+		fmt.Printf("Required bump: %s (commits: %s)\n", required, result.Bump)
+	case "gomod fix":
+		opts.GoModuleCheck = model.CheckOff
+		result, err := findNextRelease(repoPath, opts)
+		exitOnError(err)
+		dirs, err := generator.FindGoModuleDirs(repoPath, componentOptions(opts))
+		exitOnError(err)
+		if len(dirs) == 0 {
+			exitOnError(fmt.Errorf("no Go module found"))
+		}
+		for _, dir := range dirs {
+			dir = filepath.Join(repoPath, dir)
+			data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+			exitOnError(err)
+			module, err := gomod.Parse(data)
+			exitOnError(err)
+			if gomod.MatchesMajor(module.Module, result.Version.Major) {
+				fmt.Printf("Module %s matches version %s\n", module.Module, result.Version)
+				continue
+			}
+			modulePath := gomod.PathForMajor(module.Module, result.Version.Major)
+			changed, err := gomod.FixModulePath(dir, modulePath)
+			exitOnError(err)
+			dependents, err := gomod.FixDependentModules(repoPath, module.Module, modulePath, "v"+result.Version.String())
+			exitOnError(err)
+			changed = append(changed, dependents...)
+			fmt.Printf("Changed module %s to %s for version %s:\n", module.Module, modulePath, result.Version)
+			for _, file := range changed {
+				fmt.Printf("\t%s\n", file)
+			}
+		}
+	default:
+		result, err := findNextRelease(repoPath, opts)
+		exitOnError(err)
+		exitOnError(output.Write(os.Stdout, result, cfg.Output))
+	}
+}
+
+// findNextRelease computes the next release (or snapshot version) of the
+// repository, or of the selected component including the releases of its
+// dependencies.
+func findNextRelease(repoPath string, opts generator.Options) (*generator.Result, error) {
+	if _, ok := findComponent(componentName); componentName != "" && !ok {
+		return nil, fmt.Errorf("unknown component '%s'", componentName)
+	}
+	if componentName != "" && snapshot == "" {
+		results, err := generator.FindComponentVersions(repoPath, cfg.Components, cfg.PreRelease, opts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(errorExitCode)
+			return nil, err
 		}
-		fmt.Println(*version)
+		for _, result := range results {
+			if result.Component.Name == componentName {
+				printWarnings(result.Result.Warnings)
+				if explain {
+					return result.Result, output.WriteExplanation(os.Stderr, result.Result)
+				}
+				return result.Result, nil
+			}
+		}
+	}
+	var result *generator.Result
+	var err error
+	if snapshot != "" {
+		result, err = generator.FindSnapshot(repoPath, snapshot, componentOptions(opts))
+	} else if cfg.PreRelease == "" {
+		result, err = generator.FindNextVersion(repoPath, opts)
 	} else {
-		version, err := generator.FindNextRC(repoPath, conventionalCommitToSemVer, log, ignoreInvalidTags)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(errorExitCode)
+		result, err = generator.FindNextPreRelease(repoPath, cfg.PreRelease, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	printWarnings(result.Warnings)
+	if explain {
+		return result, output.WriteExplanation(os.Stderr, result)
+	}
+	return result, nil
+}
+
+// lintMessage lints the commit message in the file, or stdin for "-".
+func lintMessage(path string, rules lint.Rules) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	problems := lint.Message(lint.CleanMessage(string(data)), rules)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("commit message is not a valid conventional commit:\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+// componentOptions returns the options restricted to the selected component.
+func componentOptions(opts generator.Options) generator.Options {
+	if component, ok := findComponent(componentName); ok {
+		return generator.ComponentOptions(component, opts)
+	}
+	return opts
+}
+
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+func printComponentWarnings(results []generator.ComponentResult) {
+	for _, result := range results {
+		for _, warning := range result.Result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: component %s: %s\n", result.Component.Name, warning)
+		}
+	}
+}
+
+func findComponent(name string) (model.Component, bool) {
+	for _, component := range cfg.Components {
+		if component.Name == name {
+			return component, true
+		}
+	}
+	return model.Component{}, false
+}
+
+func loadConfig(repoPath string) {
+	path, ok := configPath, configPath != ""
+	if !ok {
+		path, ok = config.Find(repoPath)
+	}
+	if ok {
+		exitOnError(config.Load(path, cfg))
+		if cfg.DisableExit1 {
+			errorExitCode = 0
 		}
-		fmt.Println(*version)
+	}
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(errorExitCode)
 	}
 }
 
 func printHelp() {
-	fmt.Println("Usage: autosemver {version|help|[repository_path]} [options]")
+	fmt.Println("Usage: autosemver {version|help|[command] [repository_path]} [options]")
 	fmt.Println("\nCommands:")
-	fmt.Println("\t[repository_path]: path to the git repository (default: current directory)")
+	fmt.Println("\t[repository_path]: print the next version of the git repository (default: current directory)")
+	fmt.Println("\tchangelog [repository_path]: print a Markdown changelog of the next version (--all: include every reachable release)")
+	fmt.Println("\ttag [repository_path]: create the next version tag on HEAD (--annotate, -a: annotated tag; --message=\"Release {tag}\": message of the annotated tag; --push[=origin]: push the tag to the remote)")
+	fmt.Println("\tcomponents [repository_path]: print the next version of every component configured in the configuration file")
+	fmt.Println("\tmodules [repository_path]: print the next version of every Go module (from go.work or all go.mod files), tagged as <dir>/v{version}")
+	fmt.Println("\tlint [repository_path]: check that the commits since the latest release are valid conventional commits of the mapped or allowed types (--from=rev, --to=rev: commit range; --message-file=path: check a single message, - for stdin)")
+	fmt.Println("\thooks install [repository_path]: install a commit-msg git hook running lint on new commit messages (--pre-push: also lint pushed commits; --executable=path: command to run autosemver)")
+	fmt.Println("\thooks uninstall [repository_path]: remove the git hooks installed by autosemver")
+	fmt.Println("\tapidiff [repository_path]: print the changes of the exported Go API since the previous release and the required bump")
+	fmt.Println("\tgomod fix [repository_path]: change the Go module path to the major version of the next version (e.g. /v2) and rewrite its imports")
+	fmt.Println("\tconfig validate [repository_path]: validate the configuration file")
 	fmt.Println("\tversion: show the version of autosemver")
 	fmt.Println("\thelp: show this help message")
 	fmt.Println("\nOptions:")
 	fmt.Println("\t--help, -h: show this help message")
+	fmt.Println("\t--config=path, -c=path: path of the configuration file (default: .autosemver.yaml or .autosemver.yml in the repository)")
 	fmt.Println("\t--verbose, -v: enable verbose output")
-	fmt.Println("\t--release-candidate, -r: mark the version as a release candidate (append '-rc.N' to the version)")
-	fmt.Println("\t--ignore-invalid-tag, -i: ignore invalid tags (not a valid semantic version)")
+	fmt.Println("\t--explain: print a table of the evaluated commits with the rule and bump of each commit, the base of the range and the decision to stderr")
+	fmt.Println("\t--release-candidate, -r: mark the version as a release candidate (same as --pre-release=rc)")
+	fmt.Println("\t--pre-release=beta, -p=beta: mark the version as a pre-release of the given channel (append '-<channel>.N' to the version)")
+	fmt.Println("\t--ignore-invalid-tag, -i: ignore invalid tags (not a valid semantic version or not matching the tag format)")
+	fmt.Println("\t--tag-format=v{version}, -t=v{version}: format of version tags, used for reading tags and printing the next version (default: {version})")
+	fmt.Println("\t--branch-rule=release/*:1.4.x, -b=release/*: restrict versions on matching branches to a range {MAJOR.x, MAJOR.MINOR.x}, derived from the branch name if omitted")
+	fmt.Println("\t--initial-development: while the major version is 0, bump the minor version for breaking changes and the patch version for features")
+	fmt.Println("\t--promote-to-stable: release 1.0.0 if the major version is 0, regardless of the commits")
+	fmt.Println("\t--bump=minor: override the bump computed from the commits {major, minor, patch, none}")
+	fmt.Println("\t--merge-strategy=title: evaluate merge commits by their message, the pull request title in the body, the branch name or the listed commits, or skip them {message, skip, title, branch, commits} (default: message)")
+	fmt.Println("\t--squash-commits: also evaluate every commit listed as '* type: description' in a commit body, e.g. of squash merges")
+	fmt.Println("\t--first-parent: only follow the first parent of merge commits, so merged branches are evaluated by their merge commit")
+	fmt.Println("\t--ref=v1.2.0: evaluate the given branch, tag or commit instead of HEAD")
+	fmt.Println("\t--from=v1.0.0: evaluate the commits since the given revision instead of the latest release (not for lint, see above)")
+	fmt.Println("\t--target=main: predict the version after merging HEAD (or --ref) into the given branch")
+	fmt.Println("\t--snapshot[=dev]: print a version for builds between releases {dev: next version with distance and commit like 1.4.0-dev.17+g3fa2b1c, describe: previous version like 1.3.2-17-g3fa2b1c}, with .dirty for uncommitted changes")
+	fmt.Println("\t--branch=release/1.4: name of the evaluated branch for branch rules (default: current branch)")
+	fmt.Println("\t--component=svc-a: evaluate only the commits and tags of the given component of the configuration file")
+	fmt.Println("\t--go-module-check=warn: check that the Go module path matches the major version of a new release {error, warn, off} (default: error)")
+	fmt.Println("\t--api-check=warn: check that the bump is sufficient for the changes of the exported Go API since the previous release {error, warn, off} (default: off)")
+	fmt.Println("\t--output=json, -o=json: output format of the next version {text, json, env, dotenv} (default: text)")
 	fmt.Println("\t--disable-exit-1: do not exit with a non-zero code on error")
-	fmt.Println("\t--mapping=feat:minor, -m=fix:patch: add mapping for commit types (prefix) to version increments {major, minor, patch}")
-	fmt.Println("\nDefault Mapping (ignores not matching commits):")
-	for _, i := range conventionalCommitToSemVer {
+	fmt.Println("\t--mapping=feat:minor, -m=fix:patch: add mapping for commit types to version increments {major, minor, patch}")
+	fmt.Println("\nDefault Mapping (ignores not matching commits, breaking changes always bump major):")
+	for _, i := range config.Default().Mappings {
 		fmt.Printf("\t\"%s\": %s\n", i.First, i.Second)
 	}
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMain runs the command instead of the tests if AUTOSEMVER_MAIN is set, so
+// that tests can run the test binary as autosemver.
+func TestMain(m *testing.M) {
+	if os.Getenv("AUTOSEMVER_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func runMain(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "AUTOSEMVER_MAIN=1")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func commitFiles(t *testing.T, dir string, repo *git.Repository, files map[string]string, message string) {
+	t.Helper()
+
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	wt, err := repo.Worktree()
+	assert.NoError(t, err)
+	assert.NoError(t, wt.AddGlob("."))
+	_, err = wt.Commit(message, &git.CommitOptions{
+		All: true,
+		Author: &object.Signature{
+			Name:  "Test Bot",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestAPIDiff_BreakingChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	commitFiles(t, dir, repo, map[string]string{
+		"go.mod": "module example.com/m\n\ngo 1.23\n",
+		"m.go":   "package m\n\nfunc A() {}\n",
+	}, "feat: add A")
+	head, err := repo.Head()
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", head.Hash(), nil)
+	assert.NoError(t, err)
+	commitFiles(t, dir, repo, map[string]string{"m.go": "package m\n\nfunc B() {}\n"}, "feat!: replace A by B")
+
+	out, err := runMain(t, "apidiff", dir)
+
+	assert.NoError(t, err, out)
+	assert.Contains(t, out, "incompatible: ")
+	assert.Contains(t, out, "Required bump: major (commits: major)")
+}
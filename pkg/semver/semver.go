@@ -0,0 +1,226 @@
+// Package semver implements parsing, formatting, comparison and incrementing
+// of versions as specified by Semantic Versioning 2.0.0 (https://semver.org).
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrEmpty              = errors.New("version is empty")
+	ErrInvalidCore        = errors.New("version core must consist of MAJOR.MINOR.PATCH")
+	ErrLeadingZero        = errors.New("numeric identifiers must not include leading zeroes")
+	ErrInvalidIdentifier  = errors.New("identifiers must comprise only ASCII alphanumerics and hyphens")
+	ErrEmptyIdentifier    = errors.New("identifiers must not be empty")
+	ErrNumericOutOfBounds = errors.New("numeric identifier is out of bounds")
+)
+
+// SemVer is a semantic version. PreRelease and Build hold the dot separated
+// identifiers following the '-' and '+' sign respectively.
+type SemVer struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease []string
+	Build      []string
+}
+
+// Parse parses a strict semantic version like "1.2.3-beta.1+build.5".
+// A leading "v" is not accepted.
+func Parse(s string) (SemVer, error) {
+	if s == "" {
+		return SemVer{}, ErrEmpty
+	}
+
+	var version SemVer
+	rest := s
+	if i := strings.IndexByte(rest, '+'); i != -1 {
+		build, err := parseIdentifiers(rest[i+1:], false)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid build metadata in '%s': %w", s, err)
+		}
+		version.Build = build
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i != -1 {
+		preRelease, err := parseIdentifiers(rest[i+1:], true)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid pre-release in '%s': %w", s, err)
+		}
+		version.PreRelease = preRelease
+		rest = rest[:i]
+	}
+
+	core := strings.Split(rest, ".")
+	if len(core) != 3 {
+		return SemVer{}, fmt.Errorf("invalid version '%s': %w", s, ErrInvalidCore)
+	}
+	for i, target := range []*uint64{&version.Major, &version.Minor, &version.Patch} {
+		if !isNumeric(core[i]) {
+			return SemVer{}, fmt.Errorf("invalid version '%s': %w", s, ErrInvalidCore)
+		}
+		if len(core[i]) > 1 && core[i][0] == '0' {
+			return SemVer{}, fmt.Errorf("invalid version '%s': %w", s, ErrLeadingZero)
+		}
+		n, err := strconv.ParseUint(core[i], 10, 64)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid version '%s': %w", s, ErrNumericOutOfBounds)
+		}
+		*target = n
+	}
+
+	return version, nil
+}
+
+// MustParse is like Parse but panics if the version cannot be parsed.
+func MustParse(s string) SemVer {
+	version, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return version
+}
+
+// Validate returns an error describing why s is not a valid semantic version.
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+// IsValid reports whether s is a valid semantic version.
+func IsValid(s string) bool {
+	return Validate(s) == nil
+}
+
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// IsPreRelease reports whether the version has pre-release identifiers.
+func (v SemVer) IsPreRelease() bool {
+	return len(v.PreRelease) > 0
+}
+
+// Core returns the version without pre-release identifiers and build metadata.
+func (v SemVer) Core() SemVer {
+	return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// IncMajor returns the next major version, dropping pre-release and build.
+func (v SemVer) IncMajor() SemVer {
+	return SemVer{Major: v.Major + 1}
+}
+
+// IncMinor returns the next minor version, dropping pre-release and build.
+func (v SemVer) IncMinor() SemVer {
+	return SemVer{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// IncPatch returns the next patch version, dropping pre-release and build.
+func (v SemVer) IncPatch() SemVer {
+	return SemVer{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v has a lower, equal or
+// higher precedence than other. Build metadata is ignored.
+func (v SemVer) Compare(other SemVer) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if c := compareIdentifier(v.PreRelease[i], other.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.PreRelease) < len(other.PreRelease):
+		return -1
+	case len(v.PreRelease) > len(other.PreRelease):
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether v and other have the same precedence.
+func (v SemVer) Equal(other SemVer) bool {
+	return v.Compare(other) == 0
+}
+
+// LessThan reports whether v has a lower precedence than other.
+func (v SemVer) LessThan(other SemVer) bool {
+	return v.Compare(other) < 0
+}
+
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func parseIdentifiers(s string, rejectLeadingZero bool) ([]string, error) {
+	identifiers := strings.Split(s, ".")
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			return nil, ErrEmptyIdentifier
+		}
+		for _, r := range identifier {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return nil, ErrInvalidIdentifier
+			}
+		}
+		if rejectLeadingZero && len(identifier) > 1 && identifier[0] == '0' && isNumeric(identifier) {
+			return nil, ErrLeadingZero
+		}
+	}
+	return identifiers, nil
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
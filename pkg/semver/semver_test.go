@@ -0,0 +1,140 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	version, err := Parse("1.2.3-beta.x.7+build.5.sha-1a2b")
+
+	assert.NoError(t, err)
+	assert.Equal(t, SemVer{
+		Major:      1,
+		Minor:      2,
+		Patch:      3,
+		PreRelease: []string{"beta", "x", "7"},
+		Build:      []string{"build", "5", "sha-1a2b"},
+	}, version)
+	assert.Equal(t, "1.2.3-beta.x.7+build.5.sha-1a2b", version.String())
+	assert.True(t, version.IsPreRelease())
+	assert.Equal(t, "1.2.3", version.Core().String())
+}
+
+func TestParse_Valid(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{
+		"0.0.0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-0.3.7",
+		"1.0.0-x.7.z.92",
+		"1.0.0-x-y-z.--",
+		"1.0.0-alpha+001",
+		"1.0.0+20130313144700",
+		"1.0.0-beta+exp.sha.5114f85",
+		"1.0.0+21AF26D3----117B344092BD",
+		"18446744073709551615.0.0",
+	} {
+		version, err := Parse(s)
+
+		assert.NoError(t, err, s)
+		assert.Equal(t, s, version.String())
+		assert.True(t, IsValid(s), s)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for s, expected := range map[string]error{
+		"":                         ErrEmpty,
+		"1":                        ErrInvalidCore,
+		"1.2":                      ErrInvalidCore,
+		"1.2.3.4":                  ErrInvalidCore,
+		"v1.2.3":                   ErrInvalidCore,
+		"1.2.x":                    ErrInvalidCore,
+		"01.2.3":                   ErrLeadingZero,
+		"1.02.3":                   ErrLeadingZero,
+		"1.2.3-01":                 ErrLeadingZero,
+		"1.2.3-":                   ErrEmptyIdentifier,
+		"1.2.3-alpha..1":           ErrEmptyIdentifier,
+		"1.2.3+":                   ErrEmptyIdentifier,
+		"1.2.3-alpha_1":            ErrInvalidIdentifier,
+		"1.2.3+build!":             ErrInvalidIdentifier,
+		"18446744073709551616.0.0": ErrNumericOutOfBounds,
+	} {
+		_, err := Parse(s)
+
+		assert.ErrorIs(t, err, expected, s)
+		assert.False(t, IsValid(s), s)
+	}
+}
+
+func TestParse_BuildMayHaveLeadingZeros(t *testing.T) {
+	t.Parallel()
+
+	version, err := Parse("1.2.3+001")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"001"}, version.Build)
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { MustParse("invalid") })
+}
+
+func TestCompare_Precedence(t *testing.T) {
+	t.Parallel()
+
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"2.1.1",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, b := MustParse(ordered[i]), MustParse(ordered[j])
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			assert.Equal(t, expected, a.Compare(b), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestCompare_IgnoresBuildMetadata(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MustParse("1.0.0+build.1").Equal(MustParse("1.0.0+build.2")))
+	assert.True(t, MustParse("1.0.0-rc.1+a").LessThan(MustParse("1.0.0+b")))
+}
+
+func TestInc(t *testing.T) {
+	t.Parallel()
+
+	version := MustParse("1.2.3-rc.1+build.7")
+
+	assert.Equal(t, "2.0.0", version.IncMajor().String())
+	assert.Equal(t, "1.3.0", version.IncMinor().String())
+	assert.Equal(t, "1.2.4", version.IncPatch().String())
+}